@@ -15,6 +15,8 @@ import (
 type CTFdClient interface {
 	GetScoreboard() (*ScoreboardResponse, error)
 	GetChallengeList() (*ChallengeListResponse, error)
+	GetNotifications() (*NotificationListResponse, error)
+	GetChallengeSolves(challengeID int) (*ChallengeSolveListResponse, error)
 }
 
 // ctfdClient represents a CTFd API client implementation
@@ -78,6 +80,37 @@ type Challenge struct {
 	SolvedByMe     bool           `json:"solved_by_me"`
 }
 
+// ChallengeSolveListResponse represents the top-level response from the CTFd API for a challenge's solves
+type ChallengeSolveListResponse struct {
+	Success bool             `json:"success"`
+	Data    []ChallengeSolve `json:"data"`
+}
+
+// ChallengeSolve represents a single account that solved a challenge
+type ChallengeSolve struct {
+	AccountID  int    `json:"account_id"`
+	Name       string `json:"name"`
+	Date       string `json:"date"`
+	AccountURL string `json:"account_url"`
+}
+
+// NotificationListResponse represents the top-level response from the CTFd API for notifications
+type NotificationListResponse struct {
+	Success bool           `json:"success"`
+	Data    []Notification `json:"data"`
+}
+
+// Notification represents an announcement posted by the CTF admins
+type Notification struct {
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"` // markdown source
+	HTML    string `json:"html"`
+	Date    string `json:"date"`
+	UserID  *int   `json:"user_id"`
+	TeamID  *int   `json:"team_id"`
+}
+
 // NewCTFdClient creates a new CTFd client with the specified base URL and API token.
 // It configures an HTTP client with a 10-second timeout and insecure TLS verification.
 func NewCTFdClient(baseURL, apiToken string) CTFdClient {
@@ -182,3 +215,94 @@ func (c *ctfdClient) GetChallengeList() (*ChallengeListResponse, error) {
 
 	return &challengeList, nil
 }
+
+// GetNotifications fetches the admin announcements from the CTFd API.
+// Returns a NotificationListResponse containing all notifications sorted by ID or an error if the request fails.
+func (c *ctfdClient) GetNotifications() (*NotificationListResponse, error) {
+	endpoint := "/notifications"
+
+	req, err := http.NewRequest("GET", c.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", "Token "+c.apiToken)
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error response: %s", string(body))
+	}
+
+	var notifications NotificationListResponse
+	if err := json.Unmarshal(body, &notifications); err != nil {
+		return nil, fmt.Errorf("error parsing JSON response: %v", err)
+	}
+
+	if !notifications.Success {
+		return nil, fmt.Errorf("API returned success=false")
+	}
+
+	sort.Slice(notifications.Data, func(i, j int) bool {
+		return notifications.Data[i].ID < notifications.Data[j].ID
+	})
+
+	return &notifications, nil
+}
+
+// GetChallengeSolves fetches the accounts that solved a challenge from the CTFd API.
+// Returns a ChallengeSolveListResponse in the order the solves happened or an error if the request fails,
+// e.g. when the CTF hides solves from participants.
+func (c *ctfdClient) GetChallengeSolves(challengeID int) (*ChallengeSolveListResponse, error) {
+	endpoint := fmt.Sprintf("/challenges/%d/solves", challengeID)
+
+	req, err := http.NewRequest("GET", c.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", "Token "+c.apiToken)
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error response: %s", string(body))
+	}
+
+	var solves ChallengeSolveListResponse
+	if err := json.Unmarshal(body, &solves); err != nil {
+		return nil, fmt.Errorf("error parsing JSON response: %v", err)
+	}
+
+	if !solves.Success {
+		return nil, fmt.Errorf("API returned success=false")
+	}
+
+	sort.SliceStable(solves.Data, func(i, j int) bool {
+		return solves.Data[i].Date < solves.Data[j].Date
+	})
+
+	return &solves, nil
+}
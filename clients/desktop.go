@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// DesktopClient represents a client for showing alerts as freedesktop notifications
+// over the session D-Bus (org.freedesktop.Notifications)
+type DesktopClient struct {
+	AppName string
+	Icon    string
+	// Expiry in milliseconds, -1 lets the notification server decide
+	Timeout int32
+}
+
+// NewDesktopClient creates a new desktop notification client.
+// The session bus is only connected on the first alert so other commands work without one.
+func NewDesktopClient(appName, icon string, timeout int32) *DesktopClient {
+	if appName == "" {
+		appName = "ctfd-alerts"
+	}
+
+	return &DesktopClient{
+		AppName: appName,
+		Icon:    icon,
+		Timeout: timeout,
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *DesktopClient) Name() string {
+	return "desktop"
+}
+
+// DesktopUrgency maps an alert priority (1-5) onto the freedesktop urgency levels
+// 0 (low), 1 (normal) and 2 (critical)
+func DesktopUrgency(priority int) byte {
+	switch {
+	case priority >= 4:
+		return 2
+	case priority == 3:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Notify shows the alert as a desktop notification
+func (c *DesktopClient) Notify(alert *Alert) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("error connecting to session bus: %v", err)
+	}
+
+	hints := map[string]dbus.Variant{
+		"urgency": dbus.MakeVariant(DesktopUrgency(alert.Priority)),
+	}
+
+	obj := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	call := obj.Call("org.freedesktop.Notifications.Notify", 0,
+		c.AppName, uint32(0), c.Icon, alert.Title, alert.Body, []string{}, hints, c.Timeout)
+	if call.Err != nil {
+		return fmt.Errorf("error sending notification: %v", call.Err)
+	}
+
+	return nil
+}
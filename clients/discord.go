@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DiscordEmbed represents a single rich embed in a Discord webhook message
+type DiscordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	URL         string              `json:"url,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+	Thumbnail   *DiscordEmbedImage  `json:"thumbnail,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+}
+
+// DiscordEmbedImage represents an image shown inside an embed
+type DiscordEmbedImage struct {
+	URL string `json:"url"`
+}
+
+// DiscordEmbedField represents a name/value pair shown inside an embed
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// DiscordMessage represents the payload accepted by a Discord webhook
+type DiscordMessage struct {
+	Username  string         `json:"username,omitempty"`
+	AvatarURL string         `json:"avatar_url,omitempty"`
+	Content   string         `json:"content,omitempty"`
+	Embeds    []DiscordEmbed `json:"embeds,omitempty"`
+}
+
+// DiscordClient represents a client for posting alerts to a Discord webhook
+type DiscordClient struct {
+	WebhookURL string
+	Username   string
+	HTTPClient *http.Client
+}
+
+// NewDiscordClient creates a new Discord webhook client.
+// It configures an HTTP client with a 10-second timeout.
+func NewDiscordClient(webhookURL, username string) *DiscordClient {
+	return &DiscordClient{
+		WebhookURL: webhookURL,
+		Username:   username,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *DiscordClient) Name() string {
+	return "discord"
+}
+
+// discordColor maps an alert priority onto an embed sidebar color
+func discordColor(priority int) int {
+	switch {
+	case priority >= 5:
+		return 0xE74C3C // red
+	case priority == 4:
+		return 0xE67E22 // orange
+	case priority == 3:
+		return 0x3498DB // blue
+	default:
+		return 0x95A5A6 // gray
+	}
+}
+
+// NewEmbed renders an Alert as a Discord embed
+func (c *DiscordClient) NewEmbed(alert *Alert) DiscordEmbed {
+	embed := DiscordEmbed{
+		Title:       alert.Title,
+		Description: alert.Body,
+		URL:         alert.Click,
+		Color:       discordColor(alert.Priority),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if alert.Icon != "" {
+		embed.Thumbnail = &DiscordEmbedImage{URL: alert.Icon}
+	}
+
+	if alert.Challenge != nil {
+		embed.Fields = append(embed.Fields,
+			DiscordEmbedField{Name: "Category", Value: alert.Challenge.Category, Inline: true},
+			DiscordEmbedField{Name: "Points", Value: fmt.Sprintf("%d", alert.Challenge.Value), Inline: true},
+		)
+	}
+
+	if alert.Position > 0 {
+		position := fmt.Sprintf("#%d", alert.Position)
+		if alert.PreviousPosition > 0 {
+			position = fmt.Sprintf("#%d (was #%d)", alert.Position, alert.PreviousPosition)
+		}
+		embed.Fields = append(embed.Fields, DiscordEmbedField{Name: "Position", Value: position, Inline: true})
+	}
+
+	return embed
+}
+
+// SendMessage posts a structured DiscordMessage to the webhook
+func (c *DiscordClient) SendMessage(msg *DiscordMessage) error {
+	if msg.Username == "" {
+		msg.Username = c.Username
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", c.WebhookURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Notify renders the alert as an embed and posts it to the webhook
+func (c *DiscordClient) Notify(alert *Alert) error {
+	return c.SendMessage(&DiscordMessage{
+		Embeds: []DiscordEmbed{c.NewEmbed(alert)},
+	})
+}
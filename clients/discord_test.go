@@ -0,0 +1,81 @@
+package clients
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscordNotify(t *testing.T) {
+	var received DiscordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewDiscordClient(server.URL, "ctfd-alerts")
+	err := client.Notify(&Alert{
+		Title:            "CTFd Leaderboard Alert",
+		Body:             "You've been bypassed",
+		Priority:         4,
+		Click:            "https://ctf.example.com/scoreboard",
+		Icon:             "https://ctf.example.com/icon.png",
+		Challenge:        &Challenge{Category: "web", Value: 500},
+		Position:         5,
+		PreviousPosition: 4,
+	})
+	if err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if received.Username != "ctfd-alerts" {
+		t.Errorf("username = %q, want ctfd-alerts", received.Username)
+	}
+	if len(received.Embeds) != 1 {
+		t.Fatalf("got %d embeds, want 1", len(received.Embeds))
+	}
+
+	embed := received.Embeds[0]
+	if embed.Title != "CTFd Leaderboard Alert" || embed.Description != "You've been bypassed" || embed.URL != "https://ctf.example.com/scoreboard" {
+		t.Errorf("unexpected embed text: %+v", embed)
+	}
+	if embed.Color != 0xE67E22 {
+		t.Errorf("color = %#x, want the priority 4 orange", embed.Color)
+	}
+	if embed.Thumbnail == nil || embed.Thumbnail.URL != "https://ctf.example.com/icon.png" {
+		t.Errorf("thumbnail = %+v, want the alert icon", embed.Thumbnail)
+	}
+
+	want := []DiscordEmbedField{
+		{Name: "Category", Value: "web", Inline: true},
+		{Name: "Points", Value: "500", Inline: true},
+		{Name: "Position", Value: "#5 (was #4)", Inline: true},
+	}
+	if len(embed.Fields) != len(want) {
+		t.Fatalf("got fields %+v, want %+v", embed.Fields, want)
+	}
+	for i := range want {
+		if embed.Fields[i] != want[i] {
+			t.Errorf("field %d = %+v, want %+v", i, embed.Fields[i], want[i])
+		}
+	}
+}
+
+func TestDiscordNotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Unknown Webhook", "code": 10015}`))
+	}))
+	defer server.Close()
+
+	if err := NewDiscordClient(server.URL, "").Notify(&Alert{Title: "title"}); err == nil {
+		t.Fatal("Notify returned nil for a 404")
+	}
+}
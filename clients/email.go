@@ -0,0 +1,260 @@
+package clients
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmailClient represents a client for sending alerts over SMTP.
+// Alerts below ImmediatePriority are held and sent as a single digest every DigestInterval.
+type EmailClient struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+	// TLS mode: "starttls", "tls" (implicit) or "none"
+	TLSMode string
+	// Skips certificate verification, e.g. for a self-signed relay
+	InsecureSkipVerify bool
+	ImmediatePriority  int
+	DigestInterval     time.Duration
+
+	mu         sync.Mutex
+	digest     []digestEntry
+	lastDigest time.Time
+}
+
+// digestEntry records when a buffered alert was received
+type digestEntry struct {
+	Time  time.Time
+	Alert Alert
+}
+
+// NewEmailClient creates a new SMTP client.
+// Alerts with a priority of at least immediatePriority are sent right away, the rest are digested.
+func NewEmailClient(host string, port int, username, password, from string, to []string, tlsMode string, insecureSkipVerify bool, immediatePriority int, digestInterval time.Duration) *EmailClient {
+	if tlsMode == "" {
+		tlsMode = "starttls"
+	}
+
+	return &EmailClient{
+		Host:               host,
+		Port:               port,
+		Username:           username,
+		Password:           password,
+		From:               from,
+		To:                 to,
+		TLSMode:            tlsMode,
+		InsecureSkipVerify: insecureSkipVerify,
+		ImmediatePriority:  immediatePriority,
+		DigestInterval:     digestInterval,
+		lastDigest:         time.Now(),
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *EmailClient) Name() string {
+	return "email"
+}
+
+// Notify emails critical alerts immediately and buffers the rest for the digest
+func (c *EmailClient) Notify(alert *Alert) error {
+	if alert.Priority >= c.ImmediatePriority || c.DigestInterval <= 0 {
+		text, htmlBody := renderEmailAlert(alert)
+		return c.Send(alert.Title, text, htmlBody)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.digest = append(c.digest, digestEntry{Time: time.Now(), Alert: *alert})
+	return nil
+}
+
+// Flush sends the digest if the digest interval has elapsed or force is set.
+// Buffered alerts are kept if sending fails so they can be retried on the next flush.
+func (c *EmailClient) Flush(force bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !force && time.Since(c.lastDigest) < c.DigestInterval {
+		return nil
+	}
+
+	if len(c.digest) == 0 {
+		c.lastDigest = time.Now()
+		return nil
+	}
+
+	var text, htmlBody strings.Builder
+	htmlBody.WriteString("<ul>")
+	for _, entry := range c.digest {
+		fmt.Fprintf(&text, "[%s] %s\n%s\n", entry.Time.Format("15:04"), entry.Alert.Title, entry.Alert.Body)
+		fmt.Fprintf(&htmlBody, "<li><strong>%s</strong> %s<br>%s",
+			entry.Time.Format("15:04"), html.EscapeString(entry.Alert.Title), html.EscapeString(entry.Alert.Body))
+		if entry.Alert.Click != "" {
+			text.WriteString(entry.Alert.Click + "\n")
+			fmt.Fprintf(&htmlBody, ` <a href="%s">Open</a>`, html.EscapeString(entry.Alert.Click))
+		}
+		text.WriteString("\n")
+		htmlBody.WriteString("</li>")
+	}
+	htmlBody.WriteString("</ul>")
+
+	subject := fmt.Sprintf("CTFd alert digest (%d alerts)", len(c.digest))
+	if err := c.Send(subject, text.String(), htmlBody.String()); err != nil {
+		return err
+	}
+
+	c.digest = nil
+	c.lastDigest = time.Now()
+	return nil
+}
+
+// renderEmailAlert renders a single alert as plain text and HTML bodies
+func renderEmailAlert(alert *Alert) (string, string) {
+	text := alert.Body + "\n"
+	htmlBody := "<p>" + html.EscapeString(alert.Body) + "</p>"
+
+	if alert.Challenge != nil {
+		text += fmt.Sprintf("\nCategory: %s\nPoints: %d\nSolves: %d\n", alert.Challenge.Category, alert.Challenge.Value, alert.Challenge.Solves)
+		htmlBody += fmt.Sprintf("<ul><li>Category: %s</li><li>Points: %d</li><li>Solves: %d</li></ul>",
+			html.EscapeString(alert.Challenge.Category), alert.Challenge.Value, alert.Challenge.Solves)
+	}
+
+	if alert.Click != "" {
+		text += "\n" + alert.Click + "\n"
+		htmlBody += fmt.Sprintf(`<p><a href="%s">Open in CTFd</a></p>`, html.EscapeString(alert.Click))
+	}
+
+	return text, htmlBody
+}
+
+// buildMessage assembles a multipart/alternative message with plain text and HTML parts
+func (c *EmailClient) buildMessage(subject, text, htmlBody string) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", htmlBody},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", c.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(c.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// dial connects to the SMTP server using the configured TLS mode
+func (c *EmailClient) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	tlsConfig := &tls.Config{
+		ServerName:         c.Host,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	if c.TLSMode == "tls" {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, c.Host)
+	}
+
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, c.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TLSMode == "starttls" {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// Send delivers a multipart email to every configured recipient
+func (c *EmailClient) Send(subject, text, htmlBody string) error {
+	msg, err := c.buildMessage(subject, text, htmlBody)
+	if err != nil {
+		return fmt.Errorf("error building message: %v", err)
+	}
+
+	client, err := c.dial()
+	if err != nil {
+		return fmt.Errorf("error connecting to SMTP server: %v", err)
+	}
+	defer client.Close()
+
+	if c.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.Username, c.Password, c.Host)); err != nil {
+			return fmt.Errorf("error authenticating: %v", err)
+		}
+	}
+
+	if err := client.Mail(c.From); err != nil {
+		return fmt.Errorf("error setting sender: %v", err)
+	}
+
+	for _, to := range c.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("error adding recipient %s: %v", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error starting message data: %v", err)
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("error writing message: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error finishing message: %v", err)
+	}
+
+	return client.Quit()
+}
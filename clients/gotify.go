@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GotifyMessage represents a message accepted by the Gotify /message endpoint
+type GotifyMessage struct {
+	Title    string         `json:"title,omitempty"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// GotifyClient represents a client for sending alerts to a Gotify server
+type GotifyClient struct {
+	ServerURL  string
+	AppToken   string
+	HTTPClient *http.Client
+}
+
+// NewGotifyClient creates a new Gotify client using an application token.
+// It configures an HTTP client with a 10-second timeout.
+func NewGotifyClient(serverURL, appToken string) *GotifyClient {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+
+	return &GotifyClient{
+		ServerURL:  serverURL,
+		AppToken:   appToken,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *GotifyClient) Name() string {
+	return "gotify"
+}
+
+// GotifyPriority maps an alert priority (1-5) onto Gotify's 0-10 scale.
+// Gotify clients only play a sound from 4 and show a popup from 8.
+func GotifyPriority(priority int) int {
+	switch {
+	case priority >= 5:
+		return 10
+	case priority == 4:
+		return 8
+	case priority == 3:
+		return 5
+	case priority == 2:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// SendMessage sends a structured GotifyMessage
+func (c *GotifyClient) SendMessage(msg *GotifyMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", c.ServerURL+"/message", bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-Gotify-Key", c.AppToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Notify converts a generic Alert into a GotifyMessage and sends it
+func (c *GotifyClient) Notify(alert *Alert) error {
+	msg := &GotifyMessage{
+		Title:    alert.Title,
+		Message:  alert.Body,
+		Priority: GotifyPriority(alert.Priority),
+	}
+
+	if alert.Click != "" {
+		msg.Extras = map[string]any{
+			"client::notification": map[string]any{
+				"click": map[string]string{"url": alert.Click},
+			},
+		}
+	}
+
+	return c.SendMessage(msg)
+}
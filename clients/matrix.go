@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MatrixMessage represents an m.room.message event with an HTML body
+type MatrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// MatrixClient represents a client for posting alerts to a Matrix room
+type MatrixClient struct {
+	Homeserver  string
+	AccessToken string
+	RoomID      string
+	HTTPClient  *http.Client
+}
+
+// NewMatrixClient creates a new Matrix client for the specified homeserver and room.
+// It configures an HTTP client with a 10-second timeout.
+func NewMatrixClient(homeserver, accessToken, roomID string) *MatrixClient {
+	homeserver = strings.TrimSuffix(homeserver, "/")
+
+	return &MatrixClient{
+		Homeserver:  homeserver,
+		AccessToken: accessToken,
+		RoomID:      roomID,
+		HTTPClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *MatrixClient) Name() string {
+	return "matrix"
+}
+
+// NewMessage renders an Alert as a Matrix notice with plain and HTML bodies
+func (c *MatrixClient) NewMessage(alert *Alert) *MatrixMessage {
+	var plain, formatted strings.Builder
+
+	plain.WriteString(alert.Title + "\n" + alert.Body)
+	formatted.WriteString("<strong>" + html.EscapeString(alert.Title) + "</strong><br>")
+	formatted.WriteString(html.EscapeString(alert.Body))
+
+	if alert.Challenge != nil {
+		fmt.Fprintf(&plain, "\nCategory: %s | Points: %d | Solves: %d", alert.Challenge.Category, alert.Challenge.Value, alert.Challenge.Solves)
+		fmt.Fprintf(&formatted, "<ul><li>Category: <code>%s</code></li><li>Points: %d</li><li>Solves: %d</li></ul>",
+			html.EscapeString(alert.Challenge.Category), alert.Challenge.Value, alert.Challenge.Solves)
+	}
+
+	if alert.Click != "" {
+		plain.WriteString("\n" + alert.Click)
+		fmt.Fprintf(&formatted, `<br><a href="%s">Open in CTFd</a>`, html.EscapeString(alert.Click))
+	}
+
+	return &MatrixMessage{
+		MsgType:       "m.notice",
+		Body:          plain.String(),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatted.String(),
+	}
+}
+
+// SendMessage sends a structured MatrixMessage to the configured room
+func (c *MatrixClient) SendMessage(msg *MatrixMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %v", err)
+	}
+
+	// Transaction IDs only need to be unique per access token
+	txnID := fmt.Sprintf("ctfd-alerts-%d", time.Now().UnixNano())
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s", c.Homeserver, url.PathEscape(c.RoomID), txnID)
+
+	req, err := http.NewRequest("PUT", endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Authorization", "Bearer "+c.AccessToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Notify renders the alert and posts it to the configured room
+func (c *MatrixClient) Notify(alert *Alert) error {
+	return c.SendMessage(c.NewMessage(alert))
+}
@@ -0,0 +1,87 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Alert represents a backend-agnostic notification produced by the monitor
+type Alert struct {
+	ID       string        `json:"id"`    // stable event ID used for deduplication
+	Event    string        `json:"event"` // event kind, e.g. "bypass"
+	Title    string        `json:"title"`
+	Body     string        `json:"body"`
+	Priority int           `json:"priority"` // 1 (min) to 5 (max), mirrors the ntfy priority scale
+	Tags     []string      `json:"tags,omitempty"`
+	Click    string        `json:"click,omitempty"`
+	Icon     string        `json:"icon,omitempty"`     // URL of a notification icon
+	Markdown bool          `json:"markdown,omitempty"` // body is markdown, rendered by backends that support it
+	Topic    string        `json:"topic,omitempty"`    // overrides the ntfy topic when routed
+	Actions  []AlertAction `json:"actions,omitempty"`
+
+	// Optional file attached by backends that support uploads
+	Attachment     []byte `json:"attachment,omitempty"`
+	AttachmentName string `json:"attachment_name,omitempty"`
+
+	// Optional context used by backends that render structured messages
+	Challenge        *Challenge `json:"challenge,omitempty"`
+	Position         int        `json:"position,omitempty"`
+	PreviousPosition int        `json:"previous_position,omitempty"`
+}
+
+// AlertAction is a button attached to an alert by backends that support them
+type AlertAction struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+	// Empty opens URL in a browser, otherwise an HTTP request is sent in the background
+	Method  string            `json:"method,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Notifier is implemented by every backend that can deliver an Alert
+type Notifier interface {
+	Name() string
+	Notify(alert *Alert) error
+}
+
+// MultiNotifier fans a single alert out to several notifiers
+type MultiNotifier []Notifier
+
+// Name returns a static identifier for the fan-out notifier
+func (m MultiNotifier) Name() string {
+	return "multi"
+}
+
+// Notify delivers the alert to every notifier, even if some of them fail.
+// Returns a joined error describing every failed delivery.
+func (m MultiNotifier) Notify(alert *Alert) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", n.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Flusher is implemented by notifiers that buffer alerts, such as digests.
+// Flush delivers buffered alerts that are due, or all of them when force is set.
+type Flusher interface {
+	Flush(force bool) error
+}
+
+// Flush flushes every notifier that buffers alerts
+func (m MultiNotifier) Flush(force bool) error {
+	var errs []error
+	for _, n := range m {
+		if f, ok := n.(Flusher); ok {
+			if err := f.Flush(force); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", n.Name(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,12 +1,16 @@
 package clients
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -29,6 +33,17 @@ type NtfyMessage struct {
 	Delay    string           `json:"delay,omitempty"`
 }
 
+// NtfyEvent represents a single event from ntfy's JSON subscription stream
+type NtfyEvent struct {
+	ID      string   `json:"id"`
+	Time    int64    `json:"time"`
+	Event   string   `json:"event"`
+	Topic   string   `json:"topic"`
+	Message string   `json:"message"`
+	Title   string   `json:"title"`
+	Tags    []string `json:"tags"`
+}
+
 // NtfyClient represents a client for sending notifications via ntfy.sh
 type NtfyClient struct {
 	Topic      string
@@ -105,3 +120,199 @@ func (c *NtfyClient) SendMessage(msg *NtfyMessage) error {
 
 	return nil
 }
+
+// SendAttachment uploads a file along with the message fields.
+// ntfy only accepts uploads as a PUT body, so every other field is sent as a header.
+func (c *NtfyClient) SendAttachment(msg *NtfyMessage, filename string, data []byte) error {
+	topic := msg.Topic
+	if topic == "" {
+		topic = c.Topic
+	}
+
+	req, err := http.NewRequest("PUT", c.ServerURL+"/"+url.PathEscape(topic), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Filename", filename)
+	if msg.Message != "" {
+		// Headers can't contain newlines, ntfy expands the escaped form
+		req.Header.Set("Message", strings.ReplaceAll(msg.Message, "\n", `\n`))
+	}
+	if msg.Title != "" {
+		req.Header.Set("Title", msg.Title)
+	}
+	if len(msg.Tags) > 0 {
+		req.Header.Set("Tags", strings.Join(msg.Tags, ","))
+	}
+	if msg.Priority > 0 {
+		req.Header.Set("Priority", fmt.Sprintf("%d", msg.Priority))
+	}
+	if msg.Click != "" {
+		req.Header.Set("Click", msg.Click)
+	}
+	if msg.Icon != "" {
+		req.Header.Set("Icon", msg.Icon)
+	}
+	if msg.Markdown {
+		req.Header.Set("Markdown", "yes")
+	}
+	if len(msg.Actions) > 0 {
+		actions, err := json.Marshal(msg.Actions)
+		if err != nil {
+			return fmt.Errorf("error marshaling actions: %v", err)
+		}
+		req.Header.Set("Actions", string(actions))
+	}
+
+	if c.AccessToken != "" {
+		req.Header.Add("Authorization", "Bearer "+c.AccessToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *NtfyClient) Name() string {
+	return "ntfy"
+}
+
+// Notify converts a generic Alert into an NtfyMessage and sends it
+func (c *NtfyClient) Notify(alert *Alert) error {
+	msg := c.NewMessage(alert.Body)
+	msg.Title = alert.Title
+	msg.Tags = alert.Tags
+	msg.Priority = alert.Priority
+	msg.Click = alert.Click
+	msg.Icon = alert.Icon
+	msg.Markdown = alert.Markdown
+	if alert.Topic != "" {
+		msg.Topic = alert.Topic
+	}
+
+	for _, action := range alert.Actions {
+		msg.Actions = append(msg.Actions, ntfyAction(action))
+	}
+
+	if len(alert.Attachment) > 0 {
+		err := c.SendAttachment(msg, alert.AttachmentName, alert.Attachment)
+		if err == nil {
+			return nil
+		}
+		// Servers without attachment support reject the upload, so send the message on its own
+		log.Printf("Sending ntfy message without %s: %v", alert.AttachmentName, err)
+	}
+
+	return c.SendMessage(msg)
+}
+
+// ntfy sends a keepalive event about every 45 seconds, so a stream that stays silent
+// for longer has stalled without the connection being closed
+var subscribeIdleTimeout = 3 * time.Minute
+
+// Subscribe streams messages published to a topic, calling handler for each one.
+// Only messages newer than since (a message ID) are returned; pass "" for new messages only.
+// It blocks until the stream ends, the context is cancelled or an error occurs,
+// including the stream going silent for longer than subscribeIdleTimeout.
+func (c *NtfyClient) Subscribe(ctx context.Context, topic, since string, handler func(NtfyEvent)) error {
+	endpoint := fmt.Sprintf("%s/%s/json", c.ServerURL, url.PathEscape(topic))
+	if since != "" {
+		endpoint += "?since=" + url.QueryEscape(since)
+	}
+
+	// The watchdog cancels the request when nothing arrives in time
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watchdog := time.AfterFunc(subscribeIdleTimeout, cancel)
+	defer watchdog.Stop()
+
+	req, err := http.NewRequestWithContext(streamCtx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	if c.AccessToken != "" {
+		req.Header.Add("Authorization", "Bearer "+c.AccessToken)
+	}
+
+	// The stream stays open indefinitely, so the usual request timeout can't apply
+	streamClient := &http.Client{Transport: c.HTTPClient.Transport}
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() == nil && streamCtx.Err() != nil {
+			return fmt.Errorf("no response within %s", subscribeIdleTimeout)
+		}
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		watchdog.Reset(subscribeIdleTimeout)
+
+		var event NtfyEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("error parsing JSON event: %v", err)
+		}
+
+		// Skip open and keepalive events
+		if event.Event == "message" {
+			handler(event)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if streamCtx.Err() != nil {
+		return fmt.Errorf("no message or keepalive within %s", subscribeIdleTimeout)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %v", err)
+	}
+
+	return nil
+}
+
+// ntfyAction converts an AlertAction into a ntfy view or http action button
+func ntfyAction(action AlertAction) map[string]any {
+	if action.Method == "" {
+		return map[string]any{
+			"action": "view",
+			"label":  action.Label,
+			"url":    action.URL,
+		}
+	}
+
+	result := map[string]any{
+		"action": "http",
+		"label":  action.Label,
+		"url":    action.URL,
+		"method": action.Method,
+		"clear":  true,
+	}
+	if action.Body != "" {
+		result["body"] = action.Body
+	}
+	if len(action.Headers) > 0 {
+		result["headers"] = action.Headers
+	}
+	return result
+}
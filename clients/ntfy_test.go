@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withIdleTimeout shortens the subscribe watchdog for the duration of a test
+func withIdleTimeout(t *testing.T, timeout time.Duration) {
+	previous := subscribeIdleTimeout
+	subscribeIdleTimeout = timeout
+	t.Cleanup(func() { subscribeIdleTimeout = previous })
+}
+
+func TestNtfySubscribeKeepalive(t *testing.T) {
+	withIdleTimeout(t, 200*time.Millisecond)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		fmt.Fprintln(w, `{"id":"a","event":"open","topic":"control"}`)
+		flusher.Flush()
+
+		// Keepalives spaced under the timeout keep the stream open past it
+		for i := 0; i < 5; i++ {
+			time.Sleep(80 * time.Millisecond)
+			fmt.Fprintln(w, `{"id":"b","event":"keepalive","topic":"control"}`)
+			flusher.Flush()
+		}
+		fmt.Fprintln(w, `{"id":"c","event":"message","topic":"control","message":"status"}`)
+	}))
+	defer server.Close()
+
+	var messages []string
+	err := NewNtfyClient("control", server.URL, "").Subscribe(context.Background(), "control", "", func(event NtfyEvent) {
+		messages = append(messages, event.Message)
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+	if len(messages) != 1 || messages[0] != "status" {
+		t.Errorf("got messages %v, want [status]", messages)
+	}
+}
+
+func TestNtfySubscribeStalled(t *testing.T) {
+	withIdleTimeout(t, 100*time.Millisecond)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id":"a","event":"open","topic":"control"}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewNtfyClient("control", server.URL, "").Subscribe(context.Background(), "control", "", func(NtfyEvent) {})
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "keepalive") {
+			t.Errorf("Subscribe returned %v, want a stalled stream error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe did not give up on a silent stream")
+	}
+}
+
+func TestNtfySubscribeCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if err := NewNtfyClient("control", server.URL, "").Subscribe(ctx, "control", "", func(NtfyEvent) {}); err != nil {
+		t.Errorf("Subscribe returned %v after cancellation, want nil", err)
+	}
+}
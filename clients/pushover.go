@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pushoverResponse represents the envelope returned by the Pushover messages API
+type pushoverResponse struct {
+	Status int      `json:"status"`
+	Errors []string `json:"errors"`
+}
+
+// PushoverClient represents a client for sending alerts through Pushover
+type PushoverClient struct {
+	ServerURL  string
+	AppToken   string
+	UserKey    string
+	HTTPClient *http.Client
+}
+
+// NewPushoverClient creates a new Pushover client for the specified application and user.
+// It configures an HTTP client with a 10-second timeout.
+func NewPushoverClient(serverURL, appToken, userKey string) *PushoverClient {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+	if serverURL == "" {
+		serverURL = "https://api.pushover.net"
+	}
+
+	return &PushoverClient{
+		ServerURL:  serverURL,
+		AppToken:   appToken,
+		UserKey:    userKey,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *PushoverClient) Name() string {
+	return "pushover"
+}
+
+// PushoverPriority maps an alert priority (1-5) onto Pushover's -2 to 2 scale
+func PushoverPriority(priority int) int {
+	switch {
+	case priority >= 5:
+		return 2
+	case priority == 4:
+		return 1
+	case priority == 3:
+		return 0
+	case priority == 2:
+		return -1
+	default:
+		return -2
+	}
+}
+
+// Notify converts a generic Alert into a Pushover message and sends it
+func (c *PushoverClient) Notify(alert *Alert) error {
+	priority := PushoverPriority(alert.Priority)
+
+	form := url.Values{}
+	form.Set("token", c.AppToken)
+	form.Set("user", c.UserKey)
+	form.Set("title", alert.Title)
+	form.Set("message", alert.Body)
+	form.Set("priority", strconv.Itoa(priority))
+
+	// Emergency priority repeats until acknowledged and requires retry/expire
+	if priority == 2 {
+		form.Set("retry", "60")
+		form.Set("expire", "3600")
+	}
+
+	if alert.Click != "" {
+		form.Set("url", alert.Click)
+		form.Set("url_title", "Open in CTFd")
+	}
+
+	req, err := http.NewRequest("POST", c.ServerURL+"/1/messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+
+	var result pushoverResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("error parsing JSON response (status %d): %v", resp.StatusCode, err)
+	}
+
+	if result.Status != 1 {
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, strings.Join(result.Errors, ", "))
+	}
+
+	return nil
+}
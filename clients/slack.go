@@ -0,0 +1,137 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SlackText represents a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackBlock represents a single Block Kit layout block
+type SlackBlock struct {
+	Type      string       `json:"type"`
+	Text      *SlackText   `json:"text,omitempty"`
+	Fields    []SlackText  `json:"fields,omitempty"`
+	Elements  []SlackText  `json:"elements,omitempty"`
+	Accessory *SlackButton `json:"accessory,omitempty"`
+}
+
+// SlackButton represents a Block Kit link button
+type SlackButton struct {
+	Type string    `json:"type"`
+	Text SlackText `json:"text"`
+	URL  string    `json:"url"`
+}
+
+// SlackMessage represents the payload accepted by a Slack incoming webhook
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackClient represents a client for posting alerts to a Slack incoming webhook
+type SlackClient struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewSlackClient creates a new Slack incoming-webhook client.
+// It configures an HTTP client with a 10-second timeout.
+func NewSlackClient(webhookURL string) *SlackClient {
+	return &SlackClient{
+		WebhookURL: webhookURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *SlackClient) Name() string {
+	return "slack"
+}
+
+// NewBlocks renders an Alert as a list of Block Kit blocks
+func (c *SlackClient) NewBlocks(alert *Alert) []SlackBlock {
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: alert.Title}},
+	}
+
+	body := SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: alert.Body}}
+	if alert.Click != "" {
+		body.Accessory = &SlackButton{
+			Type: "button",
+			Text: SlackText{Type: "plain_text", Text: "Open"},
+			URL:  alert.Click,
+		}
+	}
+	blocks = append(blocks, body)
+
+	if alert.Challenge != nil {
+		blocks = append(blocks, SlackBlock{
+			Type: "section",
+			Fields: []SlackText{
+				{Type: "mrkdwn", Text: "*Challenge*\n" + alert.Challenge.Name},
+				{Type: "mrkdwn", Text: "*Category*\n" + alert.Challenge.Category},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Value*\n%d", alert.Challenge.Value)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Solves*\n%d", alert.Challenge.Solves)},
+			},
+		})
+	}
+
+	if alert.Position > 0 {
+		position := fmt.Sprintf("Position: *#%d*", alert.Position)
+		if alert.PreviousPosition > 0 {
+			position = fmt.Sprintf("Position: *#%d* (was #%d)", alert.Position, alert.PreviousPosition)
+		}
+		blocks = append(blocks, SlackBlock{
+			Type:     "context",
+			Elements: []SlackText{{Type: "mrkdwn", Text: position}},
+		})
+	}
+
+	return blocks
+}
+
+// SendMessage posts a structured SlackMessage to the webhook
+func (c *SlackClient) SendMessage(msg *SlackMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", c.WebhookURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Notify renders the alert as Block Kit sections and posts it to the webhook.
+// The plain text fallback is used by Slack for notifications and previews.
+func (c *SlackClient) Notify(alert *Alert) error {
+	return c.SendMessage(&SlackMessage{
+		Text:   alert.Title + ": " + alert.Body,
+		Blocks: c.NewBlocks(alert),
+	})
+}
@@ -0,0 +1,141 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TelegramMessage represents the parameters of a Bot API sendMessage call
+type TelegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode,omitempty"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview,omitempty"`
+}
+
+// telegramResponse represents the envelope returned by every Bot API method
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// TelegramClient represents a client for sending alerts through a Telegram bot
+type TelegramClient struct {
+	ServerURL      string
+	BotToken       string
+	ChatID         string
+	DisablePreview bool
+	HTTPClient     *http.Client
+}
+
+// NewTelegramClient creates a new Telegram Bot API client for the specified chat.
+// It configures an HTTP client with a 10-second timeout.
+func NewTelegramClient(serverURL, botToken, chatID string, disablePreview bool) *TelegramClient {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+	if serverURL == "" {
+		serverURL = "https://api.telegram.org"
+	}
+
+	return &TelegramClient{
+		ServerURL:      serverURL,
+		BotToken:       botToken,
+		ChatID:         chatID,
+		DisablePreview: disablePreview,
+		HTTPClient:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *TelegramClient) Name() string {
+	return "telegram"
+}
+
+// telegramEscaper escapes every character reserved by MarkdownV2
+var telegramEscaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+	"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// telegramURLEscaper escapes the characters reserved inside a MarkdownV2 link target
+var telegramURLEscaper = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+
+// EscapeMarkdownV2 escapes text so it is rendered literally by Telegram
+func EscapeMarkdownV2(text string) string {
+	return telegramEscaper.Replace(text)
+}
+
+// NewMessage renders an Alert as a MarkdownV2 formatted message
+func (c *TelegramClient) NewMessage(alert *Alert) *TelegramMessage {
+	var text strings.Builder
+
+	text.WriteString("*" + EscapeMarkdownV2(alert.Title) + "*\n")
+	text.WriteString(EscapeMarkdownV2(alert.Body))
+
+	if alert.Click != "" {
+		text.WriteString("\n[Open in CTFd](" + telegramURLEscaper.Replace(alert.Click) + ")")
+	}
+
+	return &TelegramMessage{
+		ChatID:                c.ChatID,
+		Text:                  text.String(),
+		ParseMode:             "MarkdownV2",
+		DisableWebPagePreview: c.DisablePreview,
+	}
+}
+
+// SendMessage sends a structured TelegramMessage via the sendMessage method
+func (c *TelegramClient) SendMessage(msg *TelegramMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", c.ServerURL, c.BotToken)
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// Avoid leaking the bot token, which is part of the request URL, but keep the cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("error executing request to %s: %v", c.ServerURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+
+	var result telegramResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("error parsing JSON response (status %d): %v", resp.StatusCode, err)
+	}
+
+	if !result.OK {
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, result.Description)
+	}
+
+	return nil
+}
+
+// Notify renders the alert and sends it to the configured chat
+func (c *TelegramClient) Notify(alert *Alert) error {
+	return c.SendMessage(c.NewMessage(alert))
+}
@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"#1 (was #2)", `\#1 \(was \#2\)`},
+		{"flag{a_b}", `flag\{a\_b\}`},
+		{"500 points!", `500 points\!`},
+		{`back\slash`, `back\\slash`},
+		{"a.b-c+d=e|f~g>h", `a\.b\-c\+d\=e\|f\~g\>h`},
+		{"*bold* [link] `code`", "\\*bold\\* \\[link\\] \\`code\\`"},
+	}
+
+	for _, tt := range tests {
+		if got := EscapeMarkdownV2(tt.in); got != tt.want {
+			t.Errorf("EscapeMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTelegramNotify(t *testing.T) {
+	var received TelegramMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:abc/sendMessage" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+		w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewTelegramClient(server.URL, "123:abc", "-100", true)
+	err := client.Notify(&Alert{
+		Title: "New Challenge",
+		Body:  "pwn (500 points)",
+		Click: "https://ctf.example.com/challenges#pwn-1",
+	})
+	if err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if received.ChatID != "-100" || received.ParseMode != "MarkdownV2" || !received.DisableWebPagePreview {
+		t.Errorf("unexpected message parameters: %+v", received)
+	}
+
+	want := "*New Challenge*\npwn \\(500 points\\)\n[Open in CTFd](https://ctf.example.com/challenges#pwn-1)"
+	if received.Text != want {
+		t.Errorf("text = %q, want %q", received.Text, want)
+	}
+}
+
+func TestTelegramNotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewTelegramClient(server.URL, "123:abc", "-100", false)
+	err := client.Notify(&Alert{Title: "title", Body: "body"})
+	if err == nil {
+		t.Fatal("Notify returned nil for ok=false")
+	}
+
+	if !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("error %q does not include the API description", err)
+	}
+	if strings.Contains(err.Error(), "123:abc") {
+		t.Errorf("error %q leaks the bot token", err)
+	}
+}
+
+func TestTelegramNotifyTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewTelegramClient(server.URL, "123:abc", "-100", false)
+	err := client.Notify(&Alert{Title: "title", Body: "body"})
+	if err == nil {
+		t.Fatal("Notify returned nil for an unreachable server")
+	}
+
+	if !strings.Contains(err.Error(), "dial tcp") {
+		t.Errorf("error %q does not include the cause", err)
+	}
+	if strings.Contains(err.Error(), "123:abc") {
+		t.Errorf("error %q leaks the bot token", err)
+	}
+}
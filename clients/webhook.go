@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+// DefaultWebhookTemplate renders the alert as a flat JSON object
+const DefaultWebhookTemplate = `{"event":{{json .Event}},"title":{{json .Title}},"body":{{json .Body}},"priority":{{.Priority}},"tags":{{json .Tags}},"click":{{json .Click}}}`
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of the request body when a secret is set
+const WebhookSignatureHeader = "X-Signature-256"
+
+// webhookFuncs are the helpers available inside webhook templates
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// WebhookClient represents a client for posting templated alerts to an arbitrary URL
+type WebhookClient struct {
+	URL        string
+	Method     string
+	Headers    map[string]string
+	Secret     string
+	Template   *template.Template
+	HTTPClient *http.Client
+}
+
+// NewWebhookClient creates a new generic webhook client.
+// The body template is parsed up front so mistakes are reported at startup rather than on the first alert.
+func NewWebhookClient(url, method, bodyTemplate string, headers map[string]string, secret string) (*WebhookClient, error) {
+	if method == "" {
+		method = "POST"
+	}
+
+	if bodyTemplate == "" {
+		bodyTemplate = DefaultWebhookTemplate
+	}
+
+	tmpl, err := template.New("webhook").Funcs(webhookFuncs).Parse(bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook template: %v", err)
+	}
+
+	return &WebhookClient{
+		URL:        url,
+		Method:     method,
+		Headers:    headers,
+		Secret:     secret,
+		Template:   tmpl,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Name returns the identifier used for this notifier in logs and config
+func (c *WebhookClient) Name() string {
+	return "webhook"
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload using the configured secret
+func (c *WebhookClient) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(c.Secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify renders the alert with the body template and sends it to the webhook URL
+func (c *WebhookClient) Notify(alert *Alert) error {
+	var payload bytes.Buffer
+	if err := c.Template.Execute(&payload, alert); err != nil {
+		return fmt.Errorf("error rendering template: %v", err)
+	}
+
+	req, err := http.NewRequest(c.Method, c.URL, bytes.NewReader(payload.Bytes()))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	for key, value := range c.Headers {
+		req.Header.Set(key, value)
+	}
+
+	if c.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+c.Sign(payload.Bytes()))
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error response (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
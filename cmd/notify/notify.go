@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+	"github.com/taciturnaxolotl/ctfd-alerts/cmd/serve"
+	"github.com/taciturnaxolotl/ctfd-alerts/cmd/status"
+)
+
+// NotifyCmd groups commands for working with notifiers
+var NotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Manage alert notifiers",
+}
+
+// TestCmd sends sample alerts through every notifier
+var TestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a sample of every alert type",
+	Long:  "Sends a sample of every alert type through the configured routes and reports whether each delivery worked and how long it took",
+	Run:   runTest,
+}
+
+func init() {
+	NotifyCmd.AddCommand(TestCmd)
+}
+
+func runTest(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+
+	notifiers, ok := ctx.Value("notifiers").([]clients.Notifier)
+	if !ok || len(notifiers) == 0 {
+		log.Fatal("No notifiers found in context")
+	}
+
+	// Use reflection to access config fields
+	configValue := reflect.ValueOf(ctx.Value("config")).Elem()
+	user := configValue.FieldByName("User").String()
+	site := serve.SiteURL(configValue.FieldByName("CTFdConfig").FieldByName("ApiBase").String())
+
+	overrides, _ := configValue.FieldByName("Templates").Interface().(map[string]serve.AlertTemplate)
+	policies, _ := configValue.FieldByName("Policies").Interface().(map[string]serve.EventPolicy)
+	templates, err := serve.ParseTemplates(overrides, policies)
+	if err != nil {
+		log.Fatalf("Error parsing alert templates: %v", err)
+	}
+
+	routes, _ := configValue.FieldByName("Routes").Interface().([]serve.Route)
+	router, err := serve.NewRouter(routes, notifiers)
+	if err != nil {
+		log.Fatalf("Error parsing routes: %v", err)
+	}
+
+	var rows [][]string
+	failures := 0
+	reached := make(map[string]bool)
+	for _, data := range serve.SampleData(user, site) {
+		alert, err := templates.Render(data)
+		if err != nil {
+			log.Fatalf("Error rendering %s alert: %v", data.Event, err)
+		}
+		alert.ID = fmt.Sprintf("test:%s:%d", data.Event, time.Now().Unix())
+		alert.Title = "[test] " + alert.Title
+
+		// Send the way serve would, so topic routes are exercised too
+		for _, destination := range router.Route(alert) {
+			routed := *alert
+			routed.Topic = destination.Topic
+
+			n := destination.Notifier
+			reached[n.Name()] = true
+
+			start := time.Now()
+			err := n.Notify(&routed)
+			// Digest notifiers only send on flush, so flush to test the whole path
+			if f, ok := n.(clients.Flusher); ok && err == nil {
+				err = f.Flush(true)
+			}
+			latency := time.Since(start).Round(time.Millisecond)
+
+			detail := ""
+			if err != nil {
+				failures++
+				detail = err.Error()
+			}
+
+			topic := destination.Topic
+			if topic == "" {
+				topic = "-"
+			}
+			rows = append(rows, []string{n.Name(), topic, data.Event, status.Mark(err == nil), latency.String(), detail})
+		}
+	}
+
+	fmt.Print("\n")
+	fmt.Println(status.Title(fmt.Sprintf("Notifier Test [%d notifiers]", len(notifiers))))
+	fmt.Println(status.Table([]string{"Notifier", "Topic", "Event", "OK", "Latency", "Error"}, rows))
+
+	for _, n := range notifiers {
+		if !reached[n.Name()] {
+			fmt.Printf("%s was not routed any sample alert\n", n.Name())
+		}
+	}
+
+	if failures > 0 {
+		fmt.Printf("%d deliveries failed\n", failures)
+		os.Exit(1)
+	}
+}
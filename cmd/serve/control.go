@@ -0,0 +1,188 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// controlTag marks replies published by serve so they aren't read back as commands
+const controlTag = "ctfd-alerts"
+
+// controlRetryDelay is the wait before reconnecting after the control stream drops
+const controlRetryDelay = 10 * time.Second
+
+// listenForCommands subscribes to the control topic and forwards every message as a command.
+// It reconnects whenever the stream drops, resuming after the last message seen.
+func listenForCommands(ctx context.Context, ntfy *clients.NtfyClient, commands chan<- string) {
+	var lastID string
+	for {
+		err := ntfy.Subscribe(ctx, ntfy.Topic, lastID, func(event clients.NtfyEvent) {
+			lastID = event.ID
+			if slices.Contains(event.Tags, controlTag) {
+				return
+			}
+			select {
+			case commands <- event.Message:
+			case <-ctx.Done():
+			}
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			log.Printf("Control topic stream failed, reconnecting in %s: %v", controlRetryDelay, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(controlRetryDelay):
+		}
+	}
+}
+
+// reply publishes a command response to the control topic
+func reply(ntfy *clients.NtfyClient, text string) {
+	msg := ntfy.NewMessage(text)
+	msg.Title = "ctfd-alerts"
+	msg.Tags = []string{controlTag}
+
+	if err := ntfy.SendMessage(msg); err != nil {
+		log.Printf("Failed to send command reply: %v", err)
+	}
+}
+
+// handleCommand runs a chat-ops command and returns the reply
+func (m *monitor) handleCommand(text string) string {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return commandHelp
+	}
+
+	switch fields[0] {
+	case "status":
+		return m.statusReply()
+
+	case "mute":
+		duration := time.Hour
+		if len(fields) > 1 {
+			parsed, err := time.ParseDuration(fields[1])
+			if err != nil || parsed <= 0 {
+				return fmt.Sprintf("Invalid duration %q, try e.g. mute 30m", fields[1])
+			}
+			duration = parsed
+		}
+		m.mutedUntil = time.Now().Add(duration)
+		return fmt.Sprintf("Muted alerts until %s", m.mutedUntil.Format("15:04"))
+
+	case "unmute":
+		m.mutedUntil = time.Time{}
+		return "Alerts unmuted"
+
+	case "top":
+		count := 10
+		if len(fields) > 1 {
+			parsed, err := strconv.Atoi(fields[1])
+			if err != nil || parsed <= 0 {
+				return fmt.Sprintf("Invalid count %q, try e.g. top 10", fields[1])
+			}
+			count = parsed
+		}
+		return m.topReply(count)
+
+	case "unsolved":
+		category := ""
+		if len(fields) > 1 {
+			category = strings.Join(fields[1:], " ")
+		}
+		return m.unsolvedReply(category)
+	}
+
+	return commandHelp
+}
+
+const commandHelp = `Commands:
+status - current position, score and alert state
+mute [duration] - mute alerts, e.g. mute 1h (default 1h)
+unmute - resume alerts
+top [n] - top n teams (default 10)
+unsolved [category] - challenges we haven't solved`
+
+func (m *monitor) statusReply() string {
+	var b strings.Builder
+
+	if m.state.LastScoreboard != nil {
+		if team := findTeam(m.state.LastScoreboard, m.state.UserPosition); team != nil {
+			fmt.Fprintf(&b, "%s is #%d of %d with %d points\n", m.username, team.Position, len(m.state.LastScoreboard.Data), team.Score)
+		} else {
+			fmt.Fprintf(&b, "%s is not on the scoreboard\n", m.username)
+		}
+	}
+
+	if m.state.LastChallenges != nil {
+		solved := 0
+		for _, challenge := range m.state.LastChallenges.Data {
+			if challenge.SolvedByMe {
+				solved++
+			}
+		}
+		fmt.Fprintf(&b, "Solved %d of %d challenges\n", solved, len(m.state.LastChallenges.Data))
+	}
+
+	if m.muted() {
+		fmt.Fprintf(&b, "Muted until %s\n", m.mutedUntil.Format("15:04"))
+	}
+
+	if m.quiet.Active(time.Now()) {
+		b.WriteString("Quiet hours are active\n")
+	}
+
+	fmt.Fprintf(&b, "%d alerts pending in outbox", m.outbox.Pending())
+	return b.String()
+}
+
+func (m *monitor) topReply(count int) string {
+	if m.state.LastScoreboard == nil {
+		return "No scoreboard yet"
+	}
+
+	lines := []string{fmt.Sprintf("Top %d:", count)}
+	for _, team := range m.state.LastScoreboard.Data {
+		if team.Position > count {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("#%d %s - %d", team.Position, team.Name, team.Score))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *monitor) unsolvedReply(category string) string {
+	if m.state.LastChallenges == nil {
+		return "No challenges yet"
+	}
+
+	var lines []string
+	for _, challenge := range m.state.LastChallenges.Data {
+		if challenge.SolvedByMe {
+			continue
+		}
+		if category != "" && !strings.EqualFold(challenge.Category, category) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s) - %d points, %d solves", challenge.Name, challenge.Category, challenge.Value, challenge.Solves))
+	}
+
+	if len(lines) == 0 {
+		return "Nothing left to solve 🎉"
+	}
+	return fmt.Sprintf("%d unsolved:\n%s", len(lines), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,122 @@
+package serve
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// cooldownWindow tracks alerts for one dedup key after an alert was sent
+type cooldownWindow struct {
+	event      string
+	start      time.Time
+	end        time.Time
+	suppressed int
+	last       *clients.Alert
+}
+
+// Cooldowns suppresses repeated alerts of the same kind for the same subject within a window.
+// When a window closes with suppressed alerts a single summary alert is produced.
+type Cooldowns struct {
+	durations map[string]time.Duration
+	windows   map[string]*cooldownWindow
+}
+
+// ParseCooldowns parses the per-event cooldown durations from the config, e.g. bypass = "20m"
+func ParseCooldowns(cfg map[string]string) (*Cooldowns, error) {
+	c := &Cooldowns{
+		durations: make(map[string]time.Duration),
+		windows:   make(map[string]*cooldownWindow),
+	}
+
+	for event, value := range cfg {
+		if _, ok := DefaultTemplates[event]; !ok {
+			return nil, fmt.Errorf("unknown cooldown event %q", event)
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s cooldown: %v", event, err)
+		}
+		c.durations[event] = duration
+	}
+
+	return c, nil
+}
+
+// dedupKey identifies the subject of an event, so e.g. every bypass of the user shares a key
+func dedupKey(data *AlertData) string {
+	if data.Challenge != nil {
+		return fmt.Sprintf("%s:%d", data.Event, data.Challenge.ID)
+	}
+	if data.Notification != nil {
+		return fmt.Sprintf("%s:%d", data.Event, data.Notification.ID)
+	}
+	return data.Event + ":" + data.User
+}
+
+// Allow reports whether an alert may be sent now. Alerts inside an open window are
+// counted and suppressed; otherwise a new window is opened for the key.
+func (c *Cooldowns) Allow(key, event string, alert *clients.Alert, now time.Time) bool {
+	duration := c.durations[event]
+	if duration <= 0 {
+		return true
+	}
+
+	if window, ok := c.windows[key]; ok && now.Before(window.end) {
+		window.suppressed++
+		window.last = alert
+		return false
+	}
+
+	c.windows[key] = &cooldownWindow{
+		event: event,
+		start: now,
+		end:   now.Add(duration),
+		last:  alert,
+	}
+	return true
+}
+
+// Expired closes every window that has ended and returns a summary alert
+// for each one that suppressed at least one alert
+func (c *Cooldowns) Expired(now time.Time) []*clients.Alert {
+	var summaries []*clients.Alert
+	for key, window := range c.windows {
+		if now.Before(window.end) {
+			continue
+		}
+		delete(c.windows, key)
+
+		if window.suppressed == 0 {
+			continue
+		}
+
+		summary := *window.last
+		summary.ID = fmt.Sprintf("%s:summary:%d", key, window.start.Unix())
+		summary.Body = fmt.Sprintf("%s alert flapped %d times in %s. Latest: %s",
+			window.event, window.suppressed+1, humanDuration(window.end.Sub(window.start)), window.last.Body)
+		summaries = append(summaries, &summary)
+	}
+
+	return summaries
+}
+
+// humanDuration formats whole hours and minutes the way people say them
+func humanDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		if d == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	case d >= time.Minute && d%time.Minute == 0:
+		if d == time.Minute {
+			return "1 minute"
+		}
+		return fmt.Sprintf("%d minutes", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
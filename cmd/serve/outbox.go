@@ -0,0 +1,186 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+const (
+	// outboxBaseDelay is the wait before the first retry, doubled on every further attempt
+	outboxBaseDelay = 30 * time.Second
+	// outboxMaxDelay caps the exponential backoff between retries
+	outboxMaxDelay = time.Hour
+	// outboxMaxAge is how long a delivery is retried before it is dropped
+	outboxMaxAge = 24 * time.Hour
+	// outboxDeliveredLimit bounds how many delivered event IDs are remembered for deduplication
+	outboxDeliveredLimit = 500
+)
+
+// OutboxEntry is a single failed delivery waiting to be retried
+type OutboxEntry struct {
+	ID          string        `json:"id"`
+	Notifier    string        `json:"notifier"`
+	Alert       clients.Alert `json:"alert"`
+	Attempts    int           `json:"attempts"`
+	Created     time.Time     `json:"created"`
+	NextAttempt time.Time     `json:"next_attempt"`
+	LastError   string        `json:"last_error"`
+}
+
+// Outbox is a durable queue of alerts that could not be delivered.
+// It is persisted next to the state cache so retries survive restarts.
+type Outbox struct {
+	Entries   []OutboxEntry `json:"entries"`
+	Delivered []string      `json:"delivered"`
+}
+
+func getOutboxFilePath() string {
+	return filepath.Join(".", "outbox.json")
+}
+
+// LoadOutbox reads the outbox from disk, returning an empty outbox if there is none
+func LoadOutbox() *Outbox {
+	data, err := os.ReadFile(getOutboxFilePath())
+	if err != nil {
+		return &Outbox{}
+	}
+
+	var outbox Outbox
+	if err := json.Unmarshal(data, &outbox); err != nil {
+		log.Printf("Error parsing outbox file: %v", err)
+		return &Outbox{}
+	}
+
+	return &outbox
+}
+
+// Save writes the outbox to disk
+func (o *Outbox) Save() error {
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling outbox: %v", err)
+	}
+
+	if err := os.WriteFile(getOutboxFilePath(), data, 0644); err != nil {
+		return fmt.Errorf("error writing outbox file: %v", err)
+	}
+
+	return nil
+}
+
+// Pending returns the number of deliveries waiting to be retried
+func (o *Outbox) Pending() int {
+	return len(o.Entries)
+}
+
+// Seen reports whether an event has already been delivered or queued
+func (o *Outbox) Seen(id string) bool {
+	for _, delivered := range o.Delivered {
+		if delivered == id {
+			return true
+		}
+	}
+	return false
+}
+
+// markSeen records an event ID, forgetting the oldest IDs past the limit
+func (o *Outbox) markSeen(id string) {
+	o.Delivered = append(o.Delivered, id)
+	if len(o.Delivered) > outboxDeliveredLimit {
+		o.Delivered = o.Delivered[len(o.Delivered)-outboxDeliveredLimit:]
+	}
+}
+
+// backoff returns the delay before the next attempt after the given number of failures
+func backoff(attempts int) time.Duration {
+	delay := outboxBaseDelay
+	for i := 1; i < attempts && delay < outboxMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxDelay)
+}
+
+// Deliver sends an alert to every destination, queueing failed deliveries for retry.
+// Alerts whose ID was already delivered or queued are skipped.
+func (o *Outbox) Deliver(destinations []Destination, alert *clients.Alert) error {
+	if alert.ID != "" && o.Seen(alert.ID) {
+		log.Printf("Skipping duplicate alert: %s", alert.ID)
+		return nil
+	}
+
+	var failed int
+	now := time.Now()
+	for _, destination := range destinations {
+		routed := *alert
+		routed.Topic = destination.Topic
+
+		n := destination.Notifier
+		if err := n.Notify(&routed); err != nil {
+			failed++
+			log.Printf("Failed to send alert %s via %s, queued for retry: %v", alert.ID, n.Name(), err)
+			o.Entries = append(o.Entries, OutboxEntry{
+				ID:          alert.ID,
+				Notifier:    n.Name(),
+				Alert:       routed,
+				Attempts:    1,
+				Created:     now,
+				NextAttempt: now.Add(backoff(1)),
+				LastError:   err.Error(),
+			})
+		}
+	}
+
+	if alert.ID != "" {
+		o.markSeen(alert.ID)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notifiers failed", failed, len(destinations))
+	}
+	return nil
+}
+
+// Retry re-sends every queued delivery that is due.
+// Entries for notifiers that are no longer configured, or older than outboxMaxAge, are dropped.
+func (o *Outbox) Retry(notifiers []clients.Notifier) {
+	byName := make(map[string]clients.Notifier)
+	for _, n := range notifiers {
+		byName[n.Name()] = n
+	}
+
+	now := time.Now()
+	remaining := o.Entries[:0]
+	for _, entry := range o.Entries {
+		n, ok := byName[entry.Notifier]
+		switch {
+		case !ok:
+			log.Printf("Dropping queued alert %s: notifier %s is no longer configured", entry.ID, entry.Notifier)
+			continue
+		case now.Sub(entry.Created) > outboxMaxAge:
+			log.Printf("Dropping queued alert %s for %s after %d attempts: %s", entry.ID, entry.Notifier, entry.Attempts, entry.LastError)
+			continue
+		case now.Before(entry.NextAttempt):
+			remaining = append(remaining, entry)
+			continue
+		}
+
+		if err := n.Notify(&entry.Alert); err != nil {
+			entry.Attempts++
+			entry.NextAttempt = now.Add(backoff(entry.Attempts))
+			entry.LastError = err.Error()
+			log.Printf("Retry %d of alert %s via %s failed, next attempt at %s: %v",
+				entry.Attempts, entry.ID, entry.Notifier, entry.NextAttempt.Format(time.Kitchen), err)
+			remaining = append(remaining, entry)
+			continue
+		}
+
+		log.Printf("Delivered queued alert %s via %s after %d attempts", entry.ID, entry.Notifier, entry.Attempts+1)
+	}
+	o.Entries = remaining
+}
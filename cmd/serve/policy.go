@@ -0,0 +1,77 @@
+package serve
+
+import (
+	"fmt"
+)
+
+// EventPolicy sets how urgent an event is and how it is decorated.
+// Zero fields in the config keep the default policy for the event.
+type EventPolicy struct {
+	Priority int      `toml:"priority"` // 1 (min) to 5 (max)
+	Tags     []string `toml:"tags"`     // ntfy tags, names of emoji become icons
+	Icon     string   `toml:"icon"`     // URL of a notification icon
+}
+
+// DefaultPolicies are used for any event or field not overridden in the config
+var DefaultPolicies = map[string]EventPolicy{
+	EventBypass: {
+		Priority: 4,
+		Tags:     []string{"warning", "leaderboard"},
+	},
+	EventNewChallenge: {
+		Priority: 3,
+		Tags:     []string{"challenge", "new"},
+	},
+	EventAnnouncement: {
+		Priority: 4,
+		Tags:     []string{"loudspeaker"},
+	},
+	EventSolve: {
+		Priority: 2,
+		Tags:     []string{"crossed_swords"},
+	},
+	EventFirstBlood: {
+		Priority: 4,
+		Tags:     []string{"drop_of_blood"},
+	},
+	EventTeamSolve: {
+		Priority: 3,
+		Tags:     []string{"tada"},
+	},
+	EventValueDrop: {
+		Priority: 2,
+		Tags:     []string{"chart_with_downwards_trend"},
+	},
+}
+
+// mergePolicies merges the config overrides onto the default policies
+func mergePolicies(overrides map[string]EventPolicy) (map[string]EventPolicy, error) {
+	policies := make(map[string]EventPolicy, len(DefaultPolicies))
+	for event, policy := range DefaultPolicies {
+		policies[event] = policy
+	}
+
+	for event, override := range overrides {
+		policy, ok := policies[event]
+		if !ok {
+			return nil, fmt.Errorf("unknown policy event %q", event)
+		}
+
+		if override.Priority != 0 {
+			if override.Priority < 1 || override.Priority > 5 {
+				return nil, fmt.Errorf("%s policy priority must be between 1 and 5", event)
+			}
+			policy.Priority = override.Priority
+		}
+		if override.Tags != nil {
+			policy.Tags = override.Tags
+		}
+		if override.Icon != "" {
+			policy.Icon = override.Icon
+		}
+
+		policies[event] = policy
+	}
+
+	return policies, nil
+}
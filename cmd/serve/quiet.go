@@ -0,0 +1,262 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// QuietHoursConfig configures a daily do-not-disturb window
+type QuietHoursConfig struct {
+	Start    string `toml:"start"`    // e.g. "23:00"
+	End      string `toml:"end"`      // e.g. "07:00"
+	Timezone string `toml:"timezone"` // IANA name, defaults to local time
+	// Alerts at or above this priority are delivered even during quiet hours
+	BreakThroughPriority int `toml:"break_through_priority"`
+	// Being bypassed out of the top N always breaks through
+	TopN int `toml:"top_n"`
+}
+
+// QuietHours holds non-critical alerts during a daily window and releases them as one
+// digest per destination. Held alerts are persisted so they survive restarts.
+type QuietHours struct {
+	start, end   int // minutes after midnight
+	location     *time.Location
+	breakThrough int
+	topN         int
+	held         []HeldAlert
+}
+
+// HeldAlert is an alert held during quiet hours for a single destination
+type HeldAlert struct {
+	Notifier string        `json:"notifier"`
+	Topic    string        `json:"topic"`
+	Alert    clients.Alert `json:"alert"`
+}
+
+// QuietDigest is the digest of the alerts held for one destination
+type QuietDigest struct {
+	Destination Destination
+	Alert       *clients.Alert
+}
+
+// Digest bodies are capped so they fit every backend, the tightest being Slack's
+// 3000 character section text. Alerts past the cap are counted instead of listed.
+const (
+	maxDigestBody = 2500
+	maxDigestLine = 300
+)
+
+func getHeldFilePath() string {
+	return filepath.Join(".", "held.json")
+}
+
+// parseClock parses a 24 hour "HH:MM" time into minutes after midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ParseQuietHours validates the config. Returns nil if quiet hours are not configured.
+func ParseQuietHours(cfg QuietHoursConfig) (*QuietHours, error) {
+	if cfg.Start == "" && cfg.End == "" {
+		return nil, nil
+	}
+
+	start, err := parseClock(cfg.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours start: %v", err)
+	}
+
+	end, err := parseClock(cfg.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours end: %v", err)
+	}
+
+	location := time.Local
+	if cfg.Timezone != "" {
+		if location, err = time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid quiet_hours timezone: %v", err)
+		}
+	}
+
+	breakThrough := cfg.BreakThroughPriority
+	if breakThrough == 0 {
+		breakThrough = 5
+	}
+
+	return &QuietHours{
+		start:        start,
+		end:          end,
+		location:     location,
+		breakThrough: breakThrough,
+		topN:         cfg.TopN,
+	}, nil
+}
+
+// Active reports whether now falls inside the quiet window, which may span midnight
+func (q *QuietHours) Active(now time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	local := now.In(q.location)
+	minute := local.Hour()*60 + local.Minute()
+	if q.start <= q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+// Critical reports whether an alert should break through quiet hours
+func (q *QuietHours) Critical(data *AlertData, alert *clients.Alert) bool {
+	if alert.Priority >= q.breakThrough {
+		return true
+	}
+
+	return q.topN > 0 && data.Event == EventBypass &&
+		data.OldPosition <= q.topN && (data.NewPosition > q.topN || data.NewPosition == 0)
+}
+
+// Load reads alerts held before the last shutdown from disk
+func (q *QuietHours) Load() {
+	if q == nil {
+		return
+	}
+
+	data, err := os.ReadFile(getHeldFilePath())
+	if err != nil {
+		return
+	}
+
+	if err := json.Unmarshal(data, &q.held); err != nil {
+		log.Printf("Error parsing held alerts file: %v", err)
+	}
+}
+
+// Save writes the held alerts to disk
+func (q *QuietHours) Save() error {
+	if q == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(q.held, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling held alerts: %v", err)
+	}
+
+	if err := os.WriteFile(getHeldFilePath(), data, 0644); err != nil {
+		return fmt.Errorf("error writing held alerts file: %v", err)
+	}
+
+	return nil
+}
+
+// Held returns the number of alerts waiting for quiet hours to end
+func (q *QuietHours) Held() int {
+	if q == nil {
+		return 0
+	}
+	return len(q.held)
+}
+
+// Hold keeps an alert for each of its destinations until quiet hours end
+func (q *QuietHours) Hold(destinations []Destination, alert *clients.Alert) {
+	for _, destination := range destinations {
+		q.held = append(q.held, HeldAlert{
+			Notifier: destination.Notifier.Name(),
+			Topic:    destination.Topic,
+			Alert:    *alert,
+		})
+	}
+}
+
+// Release returns one digest per destination once quiet hours are over.
+// Alerts held for notifiers that are no longer configured are dropped.
+func (q *QuietHours) Release(now time.Time, notifiers []clients.Notifier) []QuietDigest {
+	if q == nil || len(q.held) == 0 || q.Active(now) {
+		return nil
+	}
+
+	byName := make(map[string]clients.Notifier)
+	for _, n := range notifiers {
+		byName[n.Name()] = n
+	}
+
+	type key struct{ notifier, topic string }
+	var order []key
+	grouped := make(map[key][]clients.Alert)
+	for _, held := range q.held {
+		if _, ok := byName[held.Notifier]; !ok {
+			log.Printf("Dropping held alert %s: notifier %s is no longer configured", held.Alert.ID, held.Notifier)
+			continue
+		}
+
+		k := key{held.Notifier, held.Topic}
+		if _, ok := grouped[k]; !ok {
+			order = append(order, k)
+		}
+		grouped[k] = append(grouped[k], held.Alert)
+	}
+
+	digests := make([]QuietDigest, 0, len(order))
+	for _, k := range order {
+		alerts := grouped[k]
+		digest := &clients.Alert{
+			// Deliveries are deduplicated by ID, so every destination needs its own
+			ID:    fmt.Sprintf("quiet_hours:%d:%s:%s", now.Unix(), k.notifier, k.topic),
+			Title: fmt.Sprintf("Quiet hours digest (%d alerts)", len(alerts)),
+			Tags:  []string{"quiet-hours"},
+		}
+
+		lines := make([]string, 0, len(alerts))
+		for _, alert := range alerts {
+			lines = append(lines, fmt.Sprintf("• %s: %s", alert.Title, alert.Body))
+			digest.Priority = max(digest.Priority, alert.Priority)
+		}
+		digest.Body = digestBody(lines)
+
+		digests = append(digests, QuietDigest{
+			Destination: Destination{Notifier: byName[k.notifier], Topic: k.topic},
+			Alert:       digest,
+		})
+	}
+
+	q.held = nil
+	return digests
+}
+
+// digestBody joins digest lines, truncating long lines and summarising the lines that
+// don't fit within maxDigestBody as "…and N more"
+func digestBody(lines []string) string {
+	var body strings.Builder
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > maxDigestLine {
+			line = string(runes[:maxDigestLine-1]) + "…"
+		}
+		if i > 0 {
+			line = "\n" + line
+		}
+
+		// Leave room to count the remaining lines if the next one doesn't fit
+		need := len(line)
+		if i < len(lines)-1 {
+			need += len(fmt.Sprintf("\n…and %d more", len(lines)-i-1))
+		}
+		if body.Len()+need > maxDigestBody {
+			fmt.Fprintf(&body, "\n…and %d more", len(lines)-i)
+			break
+		}
+		body.WriteString(line)
+	}
+	return body.String()
+}
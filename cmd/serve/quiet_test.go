@@ -0,0 +1,143 @@
+package serve
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+func TestQuietHoursActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		clock      string
+		want       bool
+	}{
+		{"same day inside", "09:00", "17:00", "12:00", true},
+		{"same day before", "09:00", "17:00", "08:59", false},
+		{"same day at end", "09:00", "17:00", "17:00", false},
+		{"overnight before midnight", "23:00", "07:00", "23:30", true},
+		{"overnight after midnight", "23:00", "07:00", "03:00", true},
+		{"overnight at start", "23:00", "07:00", "23:00", true},
+		{"overnight at end", "23:00", "07:00", "07:00", false},
+		{"overnight daytime", "23:00", "07:00", "12:00", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuietHours(QuietHoursConfig{Start: tt.start, End: tt.end, Timezone: "UTC"})
+			if err != nil {
+				t.Fatalf("ParseQuietHours: %v", err)
+			}
+
+			clock, _ := time.Parse("15:04", tt.clock)
+			now := time.Date(2025, 3, 14, clock.Hour(), clock.Minute(), 0, 0, time.UTC)
+			if got := q.Active(now); got != tt.want {
+				t.Errorf("Active(%s) = %v, want %v", tt.clock, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursTimezone(t *testing.T) {
+	q, err := ParseQuietHours(QuietHoursConfig{Start: "23:00", End: "07:00", Timezone: "America/New_York"})
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// 04:00 UTC is midnight in New York during daylight saving time
+	if !q.Active(time.Date(2025, 7, 1, 4, 0, 0, 0, time.UTC)) {
+		t.Error("Active should use the configured timezone")
+	}
+}
+
+func TestQuietHoursDisabled(t *testing.T) {
+	q, err := ParseQuietHours(QuietHoursConfig{})
+	if err != nil || q != nil {
+		t.Fatalf("ParseQuietHours of an empty config = %v, %v, want nil, nil", q, err)
+	}
+
+	if q.Active(time.Now()) {
+		t.Error("nil quiet hours should never be active")
+	}
+}
+
+func TestQuietHoursCritical(t *testing.T) {
+	q, _ := ParseQuietHours(QuietHoursConfig{Start: "23:00", End: "07:00", TopN: 10})
+
+	tests := []struct {
+		name     string
+		data     *AlertData
+		priority int
+		want     bool
+	}{
+		{"max priority", &AlertData{Event: EventNewChallenge}, 5, true},
+		{"low priority", &AlertData{Event: EventNewChallenge}, 3, false},
+		{"bypassed out of top n", &AlertData{Event: EventBypass, OldPosition: 10, NewPosition: 11}, 4, true},
+		{"bypassed inside top n", &AlertData{Event: EventBypass, OldPosition: 3, NewPosition: 4}, 4, false},
+		{"bypassed below top n", &AlertData{Event: EventBypass, OldPosition: 12, NewPosition: 13}, 4, false},
+	}
+
+	for _, tt := range tests {
+		if got := q.Critical(tt.data, &clients.Alert{Priority: tt.priority}); got != tt.want {
+			t.Errorf("%s: Critical = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQuietHoursReleaseCapsDigest(t *testing.T) {
+	q, _ := ParseQuietHours(QuietHoursConfig{Start: "23:00", End: "07:00", Timezone: "UTC"})
+	notifiers := []clients.Notifier{namedNotifier("slack")}
+	destinations := []Destination{{Notifier: notifiers[0]}}
+
+	for i := 0; i < 500; i++ {
+		q.Hold(destinations, &clients.Alert{
+			Title:    "New CTFd Challenge",
+			Body:     fmt.Sprintf("🎯 New challenge released: challenge-%d (web) - 100 points", i),
+			Priority: 3,
+		})
+	}
+	q.Hold(destinations, &clients.Alert{Title: "Announcement", Body: strings.Repeat("a", 5000), Priority: 4})
+
+	digests := q.Release(time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC), notifiers)
+	if len(digests) != 1 {
+		t.Fatalf("got %d digests, want 1", len(digests))
+	}
+
+	digest := digests[0].Alert
+	if len(digest.Body) > maxDigestBody {
+		t.Errorf("digest body is %d bytes, want at most %d", len(digest.Body), maxDigestBody)
+	}
+	if digest.Title != "Quiet hours digest (501 alerts)" {
+		t.Errorf("title = %q, want the full alert count", digest.Title)
+	}
+	if digest.Priority != 4 {
+		t.Errorf("priority = %d, want the highest held priority", digest.Priority)
+	}
+
+	lines := strings.Split(digest.Body, "\n")
+	var more int
+	if _, err := fmt.Sscanf(lines[len(lines)-1], "…and %d more", &more); err != nil {
+		t.Fatalf("last line %q doesn't count the omitted alerts", lines[len(lines)-1])
+	}
+	if listed := len(lines) - 1; listed+more != 501 {
+		t.Errorf("%d listed + %d more, want 501 alerts", listed, more)
+	}
+	if q.Held() != 0 {
+		t.Errorf("Held = %d after release, want 0", q.Held())
+	}
+}
+
+func TestDigestBody(t *testing.T) {
+	if got := digestBody([]string{"• a: one", "• b: two"}); got != "• a: one\n• b: two" {
+		t.Errorf("digestBody = %q, want every line when they fit", got)
+	}
+
+	long := digestBody([]string{strings.Repeat("x", 1000)})
+	if runes := []rune(long); len(runes) != maxDigestLine || !strings.HasSuffix(long, "…") {
+		t.Errorf("long line is %d runes, want it truncated to %d", len(runes), maxDigestLine)
+	}
+}
@@ -0,0 +1,138 @@
+package serve
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// Route sends matching alerts to specific notifiers. Empty match fields match everything.
+type Route struct {
+	Events      []string `toml:"events"`
+	Categories  []string `toml:"categories"`
+	MinPriority int      `toml:"min_priority"`
+	MaxPriority int      `toml:"max_priority"`
+	MinValue    int      `toml:"min_value"`
+	MaxValue    int      `toml:"max_value"`
+	// Notifiers to deliver to, defaults to every notifier
+	Notifiers []string `toml:"notifiers"`
+	// Overrides the ntfy topic for this route
+	Topic string `toml:"topic"`
+	// Stops evaluating later routes when this one matches
+	Stop bool `toml:"stop"`
+}
+
+// Destination is a single notifier an alert is routed to
+type Destination struct {
+	Notifier clients.Notifier
+	Topic    string
+}
+
+// Router evaluates the route table for every alert
+type Router struct {
+	routes    []Route
+	notifiers []clients.Notifier
+}
+
+// NewRouter validates the routes against the configured notifiers
+func NewRouter(routes []Route, notifiers []clients.Notifier) (*Router, error) {
+	names := make(map[string]bool)
+	for _, n := range notifiers {
+		names[n.Name()] = true
+	}
+
+	for i, route := range routes {
+		for _, event := range route.Events {
+			if _, ok := DefaultTemplates[event]; !ok {
+				return nil, fmt.Errorf("route %d: unknown event %q", i+1, event)
+			}
+		}
+		for _, name := range route.Notifiers {
+			if !names[name] {
+				return nil, fmt.Errorf("route %d: unknown notifier %q", i+1, name)
+			}
+		}
+	}
+
+	return &Router{routes: routes, notifiers: notifiers}, nil
+}
+
+// matches reports whether the alert satisfies every match field of the route
+func (r *Route) matches(alert *clients.Alert) bool {
+	if len(r.Events) > 0 && !slices.Contains(r.Events, alert.Event) {
+		return false
+	}
+
+	if r.MinPriority > 0 && alert.Priority < r.MinPriority {
+		return false
+	}
+
+	if r.MaxPriority > 0 && alert.Priority > r.MaxPriority {
+		return false
+	}
+
+	// Challenge fields only match alerts that are about a challenge
+	if len(r.Categories) > 0 || r.MinValue > 0 || r.MaxValue > 0 {
+		if alert.Challenge == nil {
+			return false
+		}
+
+		if len(r.Categories) > 0 && !slices.ContainsFunc(r.Categories, func(category string) bool {
+			return strings.EqualFold(category, alert.Challenge.Category)
+		}) {
+			return false
+		}
+
+		if r.MinValue > 0 && alert.Challenge.Value < r.MinValue {
+			return false
+		}
+
+		if r.MaxValue > 0 && alert.Challenge.Value > r.MaxValue {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Route returns the destinations for an alert. Every matching route contributes its
+// notifiers until one with stop set; alerts that match no route go to every notifier.
+func (r *Router) Route(alert *clients.Alert) []Destination {
+	var destinations []Destination
+	seen := make(map[Destination]bool)
+	add := func(n clients.Notifier, topic string) {
+		destination := Destination{Notifier: n, Topic: topic}
+		if !seen[destination] {
+			seen[destination] = true
+			destinations = append(destinations, destination)
+		}
+	}
+
+	matched := false
+	for _, route := range r.routes {
+		if !route.matches(alert) {
+			continue
+		}
+		matched = true
+
+		for _, n := range r.notifiers {
+			if len(route.Notifiers) == 0 || slices.Contains(route.Notifiers, n.Name()) {
+				add(n, route.Topic)
+			}
+		}
+
+		if route.Stop {
+			break
+		}
+	}
+
+	if !matched {
+		for _, n := range r.notifiers {
+			add(n, "")
+		}
+	}
+
+	return destinations
+}
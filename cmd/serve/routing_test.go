@@ -0,0 +1,95 @@
+package serve
+
+import (
+	"testing"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// namedNotifier is a Notifier that only has a name, for routing tests
+type namedNotifier string
+
+func (n namedNotifier) Name() string                { return string(n) }
+func (n namedNotifier) Notify(*clients.Alert) error { return nil }
+
+// destinationNames flattens destinations into "notifier:topic" strings
+func destinationNames(destinations []Destination) []string {
+	names := make([]string, len(destinations))
+	for i, destination := range destinations {
+		names[i] = destination.Notifier.Name() + ":" + destination.Topic
+	}
+	return names
+}
+
+func TestRouterRoute(t *testing.T) {
+	notifiers := []clients.Notifier{namedNotifier("ntfy"), namedNotifier("discord"), namedNotifier("email")}
+	routes := []Route{
+		{Categories: []string{"Crypto"}, Notifiers: []string{"ntfy"}, Topic: "crypto", Stop: true},
+		{Events: []string{EventBypass}, Notifiers: []string{"discord"}},
+		{MinValue: 500, Notifiers: []string{"email"}},
+		{MinPriority: 5, Notifiers: []string{"ntfy", "discord"}},
+	}
+
+	router, err := NewRouter(routes, notifiers)
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		alert *clients.Alert
+		want  []string
+	}{
+		{
+			"category match stops",
+			&clients.Alert{Event: EventNewChallenge, Priority: 5, Challenge: &clients.Challenge{Category: "crypto", Value: 500}},
+			[]string{"ntfy:crypto"},
+		},
+		{
+			"event match",
+			&clients.Alert{Event: EventBypass, Priority: 4},
+			[]string{"discord:"},
+		},
+		{
+			"union of matches without duplicates",
+			&clients.Alert{Event: EventBypass, Priority: 5},
+			[]string{"discord:", "ntfy:"},
+		},
+		{
+			"value match",
+			&clients.Alert{Event: EventNewChallenge, Priority: 3, Challenge: &clients.Challenge{Category: "web", Value: 600}},
+			[]string{"email:"},
+		},
+		{
+			"challenge fields skip alerts without a challenge",
+			&clients.Alert{Event: EventNewChallenge, Priority: 4},
+			[]string{"ntfy:", "discord:", "email:"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := destinationNames(router.Route(tt.alert))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Route = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: Route = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestNewRouterValidation(t *testing.T) {
+	notifiers := []clients.Notifier{namedNotifier("ntfy")}
+
+	if _, err := NewRouter([]Route{{Events: []string{"nope"}}}, notifiers); err == nil {
+		t.Error("NewRouter accepted an unknown event")
+	}
+
+	if _, err := NewRouter([]Route{{Notifiers: []string{"slack"}}}, notifiers); err == nil {
+		t.Error("NewRouter accepted an unknown notifier")
+	}
+}
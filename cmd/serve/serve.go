@@ -1,13 +1,16 @@
 package serve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,31 +19,48 @@ import (
 )
 
 type MonitorState struct {
-	LastScoreboard *clients.ScoreboardResponse    `json:"last_scoreboard"`
-	LastChallenges *clients.ChallengeListResponse `json:"last_challenges"`
-	UserPosition   int                            `json:"user_position"`
+	LastScoreboard    *clients.ScoreboardResponse       `json:"last_scoreboard"`
+	LastChallenges    *clients.ChallengeListResponse    `json:"last_challenges"`
+	UserPosition      int                               `json:"user_position"`
+	LastNotifications *clients.NotificationListResponse `json:"last_notifications"`
+	// Account IDs known to have solved each challenge, by challenge ID
+	KnownSolvers map[int][]int `json:"known_solvers"`
+	// Recent value changes of each challenge, by challenge ID
+	ValueHistory map[int][]ValuePoint `json:"value_history"`
+	// Value of each challenge when serve first saw it, by challenge ID
+	FirstValues map[int]int `json:"first_values"`
+	// Value each challenge's percentage drop is measured from, by challenge ID
+	ValueBaselines map[int]int `json:"value_baselines"`
 }
 
 func getCacheFilePath() string {
 	return filepath.Join(".", "cache.json")
 }
 
-func loadStateFromCache() *MonitorState {
-	cachePath := getCacheFilePath()
-	data, err := os.ReadFile(cachePath)
+// LoadState reads the state cache written by serve
+func LoadState() (*MonitorState, error) {
+	data, err := os.ReadFile(getCacheFilePath())
 	if err != nil {
-		log.Printf("No cache file found or error reading cache: %v", err)
-		return &MonitorState{}
+		return nil, fmt.Errorf("error reading cache: %v", err)
 	}
 
 	var state MonitorState
 	if err := json.Unmarshal(data, &state); err != nil {
-		log.Printf("Error parsing cache file: %v", err)
+		return nil, fmt.Errorf("error parsing cache file: %v", err)
+	}
+
+	return &state, nil
+}
+
+func loadStateFromCache() *MonitorState {
+	state, err := LoadState()
+	if err != nil {
+		log.Printf("No cache file found or error reading cache: %v", err)
 		return &MonitorState{}
 	}
 
-	log.Printf("Loaded state from cache: %s", cachePath)
-	return &state
+	log.Printf("Loaded state from cache: %s", getCacheFilePath())
+	return state
 }
 
 func saveStateToCache(state *MonitorState) error {
@@ -60,7 +80,7 @@ func saveStateToCache(state *MonitorState) error {
 var ServeCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Run monitoring server",
-	Long:  "Continuously monitors CTFd for leaderboard changes and new challenges, sending alerts when events occur",
+	Long:  "Continuously monitors CTFd for leaderboard changes, new challenges and announcements, sending alerts when events occur",
 	Run:   runServer,
 }
 
@@ -80,14 +100,22 @@ func runServer(cmd *cobra.Command, args []string) {
 	configValue := reflect.ValueOf(config).Elem()
 	userField := configValue.FieldByName("User").String()
 	intervalField := int(configValue.FieldByName("MonitorInterval").Int())
+	site := SiteURL(configValue.FieldByName("CTFdConfig").FieldByName("ApiBase").String())
 
-	ntfyConfigField := configValue.FieldByName("NtfyConfig")
-	ntfyTopic := ntfyConfigField.FieldByName("Topic").String()
-	ntfyApiBase := ntfyConfigField.FieldByName("ApiBase").String()
-	ntfyAccessToken := ntfyConfigField.FieldByName("AccessToken").String()
+	// Get notifiers from context
+	notifiers, ok := ctx.Value("notifiers").([]clients.Notifier)
+	if !ok || len(notifiers) == 0 {
+		log.Fatal("No notifiers found in context")
+	}
+	notifier := clients.MultiNotifier(notifiers)
 
-	// Create ntfy client
-	ntfyClient := clients.NewNtfyClient(ntfyTopic, ntfyApiBase, ntfyAccessToken)
+	// Parse alert templates from config
+	overrides, _ := configValue.FieldByName("Templates").Interface().(map[string]AlertTemplate)
+	policies, _ := configValue.FieldByName("Policies").Interface().(map[string]EventPolicy)
+	templates, err := ParseTemplates(overrides, policies)
+	if err != nil {
+		log.Fatalf("Error parsing alert templates: %v", err)
+	}
 
 	// Initialize monitoring state - try to load from cache first
 	state := loadStateFromCache()
@@ -104,10 +132,86 @@ func runServer(cmd *cobra.Command, args []string) {
 		if state.LastScoreboard != nil {
 			state.UserPosition = findUserPosition(state.LastScoreboard, userField)
 		}
+		// Caches written before announcements were tracked have no notifications yet
+		if state.LastNotifications == nil {
+			updateNotifications(ctfdClient, state)
+		}
+	}
+
+	// Parse per-event cooldowns from config
+	cooldownConfig, _ := configValue.FieldByName("Cooldowns").Interface().(map[string]string)
+	cooldowns, err := ParseCooldowns(cooldownConfig)
+	if err != nil {
+		log.Fatalf("Error parsing cooldowns: %v", err)
+	}
+
+	// Parse quiet hours from config
+	quietConfig, _ := configValue.FieldByName("QuietHours").Interface().(QuietHoursConfig)
+	quiet, err := ParseQuietHours(quietConfig)
+	if err != nil {
+		log.Fatalf("Error parsing quiet hours: %v", err)
+	}
+	quiet.Load()
+	if quiet.Held() > 0 {
+		log.Printf("Loaded %d alerts held for quiet hours: %s", quiet.Held(), getHeldFilePath())
+	}
+
+	// Parse value drop alerts from config
+	valueConfig, _ := configValue.FieldByName("ValueAlerts").Interface().(ValueAlertConfig)
+	valueAlerts, err := ParseValueAlerts(valueConfig)
+	if err != nil {
+		log.Fatalf("Error parsing value alerts: %v", err)
+	}
+
+	// Build the routing table from config
+	routes, _ := configValue.FieldByName("Routes").Interface().([]Route)
+	router, err := NewRouter(routes, notifiers)
+	if err != nil {
+		log.Fatalf("Error parsing routes: %v", err)
+	}
+
+	// Load alerts that failed to send before the last shutdown
+	outbox := LoadOutbox()
+	if outbox.Pending() > 0 {
+		log.Printf("Loaded %d pending alerts from outbox: %s", outbox.Pending(), getOutboxFilePath())
+	}
+
+	m := &monitor{
+		client:    ctfdClient,
+		notifier:  notifier,
+		outbox:    outbox,
+		cooldowns: cooldowns,
+		quiet:     quiet,
+		router:    router,
+		templates: templates,
+		values:    valueAlerts,
+		state:     state,
+		username:  userField,
+		site:      site,
+		solveFeed: configValue.FieldByName("CTFdConfig").FieldByName("SolveFeed").Bool(),
+		bloods:    int(configValue.FieldByName("CTFdConfig").FieldByName("Bloods").Int()),
 	}
 
 	log.Printf("Starting monitoring server (interval: %d seconds)", intervalField)
 	log.Printf("Monitoring user: %s", userField)
+	for _, n := range notifiers {
+		log.Printf("Sending alerts via: %s", n.Name())
+	}
+
+	// Listen for chat-ops commands on the ntfy control topic
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	commands := make(chan string)
+	ntfyConfigField := configValue.FieldByName("NtfyConfig")
+	m.attachScoreboard = ntfyConfigField.FieldByName("AttachScoreboard").Bool()
+	var control *clients.NtfyClient
+	if controlTopic := ntfyConfigField.FieldByName("ControlTopic").String(); controlTopic != "" {
+		control = clients.NewNtfyClient(controlTopic, ntfyConfigField.FieldByName("ApiBase").String(), ntfyConfigField.FieldByName("AccessToken").String())
+		m.control = control
+		go listenForCommands(ctx, control, commands)
+		log.Printf("Listening for commands on ntfy topic: %s", controlTopic)
+	}
 
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -120,7 +224,7 @@ func runServer(cmd *cobra.Command, args []string) {
 	for {
 		select {
 		case <-ticker.C:
-			if err := monitorAndAlert(ctfdClient, ntfyClient, state, userField); err != nil {
+			if err := m.monitorAndAlert(); err != nil {
 				log.Printf("Error during monitoring: %v", err)
 			} else {
 				// Save state to cache after successful monitoring
@@ -128,8 +232,34 @@ func runServer(cmd *cobra.Command, args []string) {
 					log.Printf("Error saving state to cache: %v", err)
 				}
 			}
+
+			// Summarize alerts suppressed by cooldowns that have ended
+			m.closeCooldowns()
+
+			// Deliver alerts held during quiet hours once they are over
+			m.releaseQuietHours()
+
+			// Send any digests that are due
+			if err := notifier.Flush(false); err != nil {
+				log.Printf("Error flushing notifiers: %v", err)
+			}
+
+			// Retry alerts that failed to send
+			if outbox.Pending() > 0 {
+				outbox.Retry(notifier)
+				if err := outbox.Save(); err != nil {
+					log.Printf("Error saving outbox: %v", err)
+				}
+				log.Printf("%d alerts pending in outbox", outbox.Pending())
+			}
+		case text := <-commands:
+			log.Printf("Received command: %s", text)
+			reply(control, m.handleCommand(text))
 		case <-sigChan:
 			log.Println("Received shutdown signal, saving state and stopping server...")
+			if err := notifier.Flush(true); err != nil {
+				log.Printf("Error flushing notifiers: %v", err)
+			}
 			if err := saveStateToCache(state); err != nil {
 				log.Printf("Error saving final state to cache: %v", err)
 			} else {
@@ -157,38 +287,264 @@ func updateState(client clients.CTFdClient, state *MonitorState, username string
 		return fmt.Errorf("failed to get challenges: %v", err)
 	}
 	state.LastChallenges = challenges
+	state.recordValues(challenges, time.Now())
+
+	updateNotifications(client, state)
 
 	return nil
 }
 
-func monitorAndAlert(client clients.CTFdClient, ntfy *clients.NtfyClient, state *MonitorState, username string) error {
+// updateNotifications records the current announcements without alerting on them.
+// Announcements are optional since some CTFs block the notifications endpoint.
+func updateNotifications(client clients.CTFdClient, state *MonitorState) {
+	notifications, err := client.GetNotifications()
+	if err != nil {
+		log.Printf("Failed to get notifications, skipping announcements: %v", err)
+		return
+	}
+	state.LastNotifications = notifications
+}
+
+// monitor bundles everything needed to poll CTFd and send alerts
+type monitor struct {
+	client    clients.CTFdClient
+	notifier  clients.MultiNotifier
+	outbox    *Outbox
+	cooldowns *Cooldowns
+	quiet     *QuietHours
+	router    *Router
+	templates *Templates
+	values    *ValueAlerts
+	state     *MonitorState
+	username  string
+	site      string
+
+	// Alert when other teams solve challenges
+	solveFeed bool
+
+	// How many bloods to alert on per challenge
+	bloods int
+
+	// Attach a PNG of the scoreboard around the user to bypass alerts
+	attachScoreboard bool
+
+	// Control topic client, used for the mute action button
+	control *clients.NtfyClient
+
+	// Set by the mute control command
+	mutedUntil time.Time
+}
+
+// alert renders the event with its template and sends it to every notifier
+func (m *monitor) alert(data *AlertData) {
+	data.User = m.username
+	data.Site = m.site
+	if data.Challenge != nil {
+		data.ChallengeURL = challengeURL(m.site, *data.Challenge)
+	}
+
+	alert, err := m.templates.Render(data)
+	if err != nil {
+		log.Printf("Failed to render %s alert: %v", data.Event, err)
+		return
+	}
+	alert.ID = eventID(data)
+	alert.Actions = m.actions(data)
+	// Cooldowns run first so every repeat of a flapping event is counted
+	if !m.cooldowns.Allow(dedupKey(data), data.Event, alert, time.Now()) {
+		log.Printf("Suppressed %s alert during cooldown: %s", data.Event, alert.Body)
+		return
+	}
+
+	if m.outbox.Seen(alert.ID) {
+		log.Printf("Skipping duplicate %s alert: %s", data.Event, alert.ID)
+		return
+	}
+
+	if m.muted() {
+		log.Printf("Dropped %s alert while muted: %s", data.Event, alert.Body)
+		return
+	}
+
+	if m.quiet.Active(time.Now()) && !m.quiet.Critical(data, alert) {
+		log.Printf("Holding %s alert until quiet hours end: %s", data.Event, alert.Body)
+		m.quiet.Hold(m.router.Route(alert), alert)
+		if err := m.quiet.Save(); err != nil {
+			log.Printf("Error saving held alerts: %v", err)
+		}
+
+		// Held alerts count as seen so they aren't held again after a restart
+		m.outbox.markSeen(alert.ID)
+		if err := m.outbox.Save(); err != nil {
+			log.Printf("Error saving outbox: %v", err)
+		}
+		return
+	}
+
+	// Only rendered once the alert is actually going out
+	if m.attachScoreboard && data.Event == EventBypass && data.Scoreboard != nil {
+		snapshot, err := RenderScoreboardSnapshot(data.Scoreboard, m.state.LastScoreboard, data.NewPosition)
+		if err != nil {
+			log.Printf("Failed to render scoreboard snapshot: %v", err)
+		} else {
+			alert.Attachment = snapshot
+			alert.AttachmentName = "scoreboard.png"
+		}
+	}
+
+	m.send(data.Event, alert)
+}
+
+// actions builds the buttons attached to an alert
+func (m *monitor) actions(data *AlertData) []clients.AlertAction {
+	var actions []clients.AlertAction
+	if data.Challenge != nil {
+		actions = append(actions, clients.AlertAction{Label: "Open challenge", URL: data.ChallengeURL})
+	}
+	actions = append(actions, clients.AlertAction{Label: "Open scoreboard", URL: m.site + "/scoreboard"})
+
+	// Publishing "mute 1h" to the control topic is picked up by listenForCommands.
+	// Actions are readable by anyone subscribed to the alert topic, so the button is
+	// left out when publishing needs the access token.
+	if m.control != nil && m.control.AccessToken == "" {
+		actions = append(actions, clients.AlertAction{
+			Label:  "Mute 1h",
+			URL:    m.control.ServerURL + "/" + url.PathEscape(m.control.Topic),
+			Method: "POST",
+			Body:   "mute 1h",
+		})
+	}
+
+	return actions
+}
+
+// muted reports whether alerts are muted from the control topic
+func (m *monitor) muted() bool {
+	return time.Now().Before(m.mutedUntil)
+}
+
+// send delivers an alert to every routed notifier through the outbox.
+// Alerts are dropped while muted, like the alerts they summarise would have been.
+func (m *monitor) send(event string, alert *clients.Alert) {
+	if m.muted() {
+		log.Printf("Dropped %s alert while muted: %s", event, alert.Body)
+		return
+	}
+
+	m.deliver(event, m.router.Route(alert), alert)
+}
+
+// deliver sends an alert to the given destinations through the outbox
+func (m *monitor) deliver(event string, destinations []Destination, alert *clients.Alert) {
+	if err := m.outbox.Deliver(destinations, alert); err != nil {
+		log.Printf("Failed to send %s alert: %v", event, err)
+	} else {
+		log.Printf("Sent %s alert: %s", event, alert.Body)
+	}
+
+	if err := m.outbox.Save(); err != nil {
+		log.Printf("Error saving outbox: %v", err)
+	}
+}
+
+// releaseQuietHours sends held alerts as one digest per destination once quiet hours are over.
+// While muted they stay held, so the digest goes out once the mute ends.
+func (m *monitor) releaseQuietHours() {
+	if m.muted() {
+		return
+	}
+
+	held := m.quiet.Held()
+	for _, digest := range m.quiet.Release(time.Now(), m.notifier) {
+		m.deliver("quiet hours digest", []Destination{digest.Destination}, digest.Alert)
+	}
+
+	if m.quiet.Held() != held {
+		if err := m.quiet.Save(); err != nil {
+			log.Printf("Error saving held alerts: %v", err)
+		}
+	}
+}
+
+// closeCooldowns sends a summary for every cooldown window that ended with suppressed alerts
+func (m *monitor) closeCooldowns() {
+	for _, summary := range m.cooldowns.Expired(time.Now()) {
+		m.send("cooldown summary", summary)
+	}
+}
+
+// eventID identifies an event so the same event is never alerted twice,
+// e.g. when it is detected again after a restart before the cache was saved
+func eventID(data *AlertData) string {
+	switch data.Event {
+	case EventBypass:
+		// The passing teams' scores tell a repeat flip between the same positions
+		// apart from the same flip being detected again
+		id := fmt.Sprintf("%s:%d->%d:%d", data.Event, data.OldPosition, data.NewPosition, data.Score)
+		for _, team := range data.Teams {
+			id += fmt.Sprintf(":%d=%d", team.AccountID, team.Score)
+		}
+		return id
+	case EventAnnouncement:
+		return fmt.Sprintf("%s:%d", data.Event, data.Notification.ID)
+	case EventSolve:
+		return fmt.Sprintf("%s:%d:%d", data.Event, data.Challenge.ID, data.Solve.AccountID)
+	case EventFirstBlood:
+		return fmt.Sprintf("%s:%d:%d", data.Event, data.Challenge.ID, data.Blood)
+	case EventValueDrop:
+		return fmt.Sprintf("%s:%d:%d", data.Event, data.Challenge.ID, data.Challenge.Value)
+	default:
+		if data.Challenge != nil {
+			return fmt.Sprintf("%s:%d", data.Event, data.Challenge.ID)
+		}
+		return data.Event
+	}
+}
+
+func (m *monitor) monitorAndAlert() error {
+	state := m.state
+
 	// Get current scoreboard
-	currentScoreboard, err := client.GetScoreboard()
+	currentScoreboard, err := m.client.GetScoreboard()
 	if err != nil {
 		return fmt.Errorf("failed to get scoreboard: %v", err)
 	}
 
 	// Get current challenges
-	currentChallenges, err := client.GetChallengeList()
+	currentChallenges, err := m.client.GetChallengeList()
 	if err != nil {
 		return fmt.Errorf("failed to get challenges: %v", err)
 	}
 
+	// Get current notifications, announcements are skipped if the endpoint is unavailable
+	currentNotifications, err := m.client.GetNotifications()
+	if err != nil {
+		log.Printf("Failed to get notifications, skipping announcements: %v", err)
+	}
+
+	previousPosition := state.UserPosition
+	currentPosition := findUserPosition(currentScoreboard, m.username)
+
 	// Check for leaderboard bypass
 	if state.LastScoreboard != nil {
-		currentPosition := findUserPosition(currentScoreboard, username)
 		if currentPosition > state.UserPosition && state.UserPosition > 0 {
 			// User was bypassed
-			msg := ntfy.NewMessage(fmt.Sprintf("🏆 You've been bypassed on the leaderboard! New position: #%d (was #%d)", currentPosition, state.UserPosition))
-			msg.Title = "CTFd Leaderboard Alert"
-			msg.Tags = []string{"warning", "leaderboard"}
-			msg.Priority = 4
-
-			if err := ntfy.SendMessage(msg); err != nil {
-				log.Printf("Failed to send bypass alert: %v", err)
-			} else {
-				log.Printf("Sent bypass alert: %s -> %d", username, currentPosition)
+			data := &AlertData{
+				Event:       EventBypass,
+				OldPosition: state.UserPosition,
+				NewPosition: currentPosition,
+				Team:        findTeam(currentScoreboard, currentPosition),
+				Teams:       findBypassingTeams(currentScoreboard, state.UserPosition, currentPosition),
+				Scoreboard:  currentScoreboard,
+			}
+			if team := findTeam(state.LastScoreboard, state.UserPosition); team != nil {
+				data.OldScore = team.Score
 			}
+			if data.Team != nil {
+				data.Score = data.Team.Score
+			}
+
+			m.alert(data)
 		}
 		state.UserPosition = currentPosition
 	}
@@ -196,27 +552,83 @@ func monitorAndAlert(client clients.CTFdClient, ntfy *clients.NtfyClient, state
 	// Check for new challenges
 	if state.LastChallenges != nil {
 		newChallenges := findNewChallenges(state.LastChallenges, currentChallenges)
-		for _, challenge := range newChallenges {
-			msg := ntfy.NewMessage(fmt.Sprintf("🎯 New challenge released: %s (%s) - %d points", challenge.Name, challenge.Category, challenge.Value))
-			msg.Title = "New CTFd Challenge"
-			msg.Tags = []string{"challenge", "new"}
-			msg.Priority = 3
-
-			if err := ntfy.SendMessage(msg); err != nil {
-				log.Printf("Failed to send new challenge alert: %v", err)
-			} else {
-				log.Printf("Sent new challenge alert: %s", challenge.Name)
+		for i := range newChallenges {
+			m.alert(&AlertData{
+				Event:     EventNewChallenge,
+				Challenge: &newChallenges[i],
+			})
+		}
+	}
+
+	// Check for challenges our team solved
+	if state.LastChallenges != nil {
+		solved := findSolvedChallenges(state.LastChallenges, currentChallenges)
+		for i := range solved {
+			data := &AlertData{
+				Event:       EventTeamSolve,
+				Challenge:   &solved[i],
+				OldPosition: previousPosition,
+				NewPosition: currentPosition,
+				Team:        findTeam(currentScoreboard, currentPosition),
 			}
+			if state.LastScoreboard != nil {
+				if team := findTeam(state.LastScoreboard, previousPosition); team != nil {
+					data.OldScore = team.Score
+				}
+			}
+			if data.Team != nil {
+				data.Score = data.Team.Score
+			}
+
+			m.alert(data)
+		}
+	}
+
+	// Check for bloods and solves by other teams
+	if state.LastChallenges != nil {
+		m.checkSolves(state.LastChallenges, currentChallenges, currentScoreboard)
+	}
+
+	// Check for unsolved challenges losing value
+	if state.LastChallenges != nil {
+		for _, data := range m.values.Check(state, state.LastChallenges, currentChallenges) {
+			m.alert(data)
+		}
+	}
+	state.recordValues(currentChallenges, time.Now())
+
+	// Check for new announcements
+	if state.LastNotifications != nil && currentNotifications != nil {
+		newNotifications := findNewNotifications(state.LastNotifications, currentNotifications)
+		for i := range newNotifications {
+			m.alert(&AlertData{
+				Event:        EventAnnouncement,
+				Notification: &newNotifications[i],
+			})
 		}
 	}
 
 	// Update state
 	state.LastScoreboard = currentScoreboard
 	state.LastChallenges = currentChallenges
+	if currentNotifications != nil {
+		state.LastNotifications = currentNotifications
+	}
 
 	return nil
 }
 
+// SiteURL derives the CTFd web root from the configured API base URL
+func SiteURL(apiBase string) string {
+	apiBase = strings.TrimSuffix(apiBase, "/")
+	return strings.TrimSuffix(apiBase, "/api/v1")
+}
+
+// challengeURL links to a challenge's modal on the CTFd challenges page
+func challengeURL(site string, challenge clients.Challenge) string {
+	return fmt.Sprintf("%s/challenges#%s-%d", site, url.PathEscape(challenge.Name), challenge.ID)
+}
+
 func findUserPosition(scoreboard *clients.ScoreboardResponse, username string) int {
 	for _, team := range scoreboard.Data {
 		if team.Name == username {
@@ -232,6 +644,27 @@ func findUserPosition(scoreboard *clients.ScoreboardResponse, username string) i
 	return 0 // User not found
 }
 
+// findTeam returns the scoreboard entry at the given position, if any
+func findTeam(scoreboard *clients.ScoreboardResponse, position int) *clients.TeamStanding {
+	for i, team := range scoreboard.Data {
+		if team.Position == position {
+			return &scoreboard.Data[i]
+		}
+	}
+	return nil
+}
+
+// findBypassingTeams returns the teams that moved into the positions the user lost
+func findBypassingTeams(scoreboard *clients.ScoreboardResponse, oldPosition, newPosition int) []clients.TeamStanding {
+	var teams []clients.TeamStanding
+	for _, team := range scoreboard.Data {
+		if team.Position >= oldPosition && team.Position < newPosition {
+			teams = append(teams, team)
+		}
+	}
+	return teams
+}
+
 func findNewChallenges(oldChallenges, newChallenges *clients.ChallengeListResponse) []clients.Challenge {
 	oldMap := make(map[int]bool)
 	for _, challenge := range oldChallenges.Data {
@@ -247,3 +680,37 @@ func findNewChallenges(oldChallenges, newChallenges *clients.ChallengeListRespon
 
 	return newOnes
 }
+
+// findNewNotifications returns the announcements posted since the last poll
+func findNewNotifications(oldNotifications, newNotifications *clients.NotificationListResponse) []clients.Notification {
+	lastID := 0
+	for _, notification := range oldNotifications.Data {
+		lastID = max(lastID, notification.ID)
+	}
+
+	var newOnes []clients.Notification
+	for _, notification := range newNotifications.Data {
+		if notification.ID > lastID {
+			newOnes = append(newOnes, notification)
+		}
+	}
+
+	return newOnes
+}
+
+// findSolvedChallenges returns the challenges the user's team solved since the last poll
+func findSolvedChallenges(oldChallenges, newChallenges *clients.ChallengeListResponse) []clients.Challenge {
+	oldMap := make(map[int]bool)
+	for _, challenge := range oldChallenges.Data {
+		oldMap[challenge.ID] = challenge.SolvedByMe
+	}
+
+	var solved []clients.Challenge
+	for _, challenge := range newChallenges.Data {
+		if challenge.SolvedByMe && !oldMap[challenge.ID] {
+			solved = append(solved, challenge)
+		}
+	}
+
+	return solved
+}
@@ -0,0 +1,113 @@
+package serve
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// snapshotRadius is how many teams above and below the user are shown
+	snapshotRadius = 3
+	// snapshotScale upscales the bitmap font so it stays readable on phones
+	snapshotScale     = 2
+	snapshotRowHeight = 18
+	snapshotPadding   = 8
+	snapshotWidth     = 360
+)
+
+var (
+	snapshotBackground = color.RGBA{0x1E, 0x1E, 0x2E, 0xFF}
+	snapshotText       = color.RGBA{0xCD, 0xD6, 0xF4, 0xFF}
+	snapshotHeader     = color.RGBA{0x7D, 0x56, 0xF4, 0xFF}
+	snapshotHighlight  = color.RGBA{0x45, 0x47, 0x5A, 0xFF}
+	snapshotUp         = color.RGBA{0x73, 0xF5, 0x9F, 0xFF}
+	snapshotDown       = color.RGBA{0xF3, 0x8B, 0xA8, 0xFF}
+)
+
+// snapshotColumns are the x offsets of the Pos, Team, Score and delta columns
+var snapshotColumns = []int{snapshotPadding, 48, 240, 300}
+
+// RenderScoreboardSnapshot draws the teams around the given position as a PNG.
+// Score deltas are computed against the previous scoreboard when one is given.
+func RenderScoreboardSnapshot(current, previous *clients.ScoreboardResponse, position int) ([]byte, error) {
+	previousScores := make(map[int]int)
+	if previous != nil {
+		for _, team := range previous.Data {
+			previousScores[team.AccountID] = team.Score
+		}
+	}
+
+	var teams []clients.TeamStanding
+	for _, team := range current.Data {
+		if team.Position >= position-snapshotRadius && team.Position <= position+snapshotRadius {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) == 0 {
+		return nil, fmt.Errorf("no teams around position %d", position)
+	}
+
+	height := snapshotPadding*2 + snapshotRowHeight*(len(teams)+1)
+	img := image.NewRGBA(image.Rect(0, 0, snapshotWidth, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{snapshotBackground}, image.Point{}, draw.Src)
+
+	drawText := func(x, row int, c color.Color, text string) {
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  &image.Uniform{c},
+			Face: basicfont.Face7x13,
+			Dot:  fixed.P(x, snapshotPadding+row*snapshotRowHeight+13),
+		}
+		d.DrawString(text)
+	}
+
+	for i, header := range []string{"Pos", "Team", "Score", "+/-"} {
+		drawText(snapshotColumns[i], 0, snapshotHeader, header)
+	}
+
+	for i, team := range teams {
+		row := i + 1
+		if team.Position == position {
+			y := snapshotPadding + row*snapshotRowHeight
+			draw.Draw(img, image.Rect(0, y, snapshotWidth, y+snapshotRowHeight), &image.Uniform{snapshotHighlight}, image.Point{}, draw.Src)
+		}
+
+		name := team.Name
+		if runes := []rune(name); len(runes) > 26 {
+			name = string(runes[:23]) + "..."
+		}
+
+		drawText(snapshotColumns[0], row, snapshotText, fmt.Sprintf("#%d", team.Position))
+		drawText(snapshotColumns[1], row, snapshotText, name)
+		drawText(snapshotColumns[2], row, snapshotText, fmt.Sprintf("%d", team.Score))
+
+		if old, ok := previousScores[team.AccountID]; ok && team.Score != old {
+			// Scores can drop when dynamic challenges lose value or awards are removed
+			delta := snapshotUp
+			if team.Score < old {
+				delta = snapshotDown
+			}
+			drawText(snapshotColumns[3], row, delta, fmt.Sprintf("%+d", team.Score-old))
+		}
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, snapshotWidth*snapshotScale, height*snapshotScale))
+	xdraw.NearestNeighbor.Scale(scaled, scaled.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, scaled); err != nil {
+		return nil, fmt.Errorf("error encoding PNG: %v", err)
+	}
+
+	return out.Bytes(), nil
+}
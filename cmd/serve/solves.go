@@ -0,0 +1,128 @@
+package serve
+
+import (
+	"log"
+	"slices"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// checkSolves alerts on bloods and, with the solve feed on, every solve by another team
+// since the last poll. Solvers are only fetched for challenges whose solve count went up.
+func (m *monitor) checkSolves(oldChallenges, newChallenges *clients.ChallengeListResponse, scoreboard *clients.ScoreboardResponse) {
+	previousSolves := make(map[int]int)
+	previouslySolvedByMe := make(map[int]bool)
+	for _, challenge := range oldChallenges.Data {
+		previousSolves[challenge.ID] = challenge.Solves
+		previouslySolvedByMe[challenge.ID] = challenge.SolvedByMe
+	}
+
+	ourAccount := findUserAccountID(scoreboard, m.username)
+	for i := range newChallenges.Data {
+		challenge := &newChallenges.Data[i]
+		previous := previousSolves[challenge.ID]
+		if challenge.Solves <= previous {
+			continue
+		}
+
+		// Only the first few solves are bloods
+		bloods := previous < m.bloods
+		if !bloods && !m.solveFeed {
+			continue
+		}
+
+		solves, err := m.client.GetChallengeSolves(challenge.ID)
+		if err != nil {
+			log.Printf("Failed to get solves for %s: %v", challenge.Name, err)
+
+			// Without the solvers, bloods still follow from the solve counter
+			if bloods {
+				// The new solve can only be ours if it's the only one
+				ours := challenge.Solves == previous+1 && challenge.SolvedByMe && !previouslySolvedByMe[challenge.ID]
+				for rank := previous + 1; rank <= min(m.bloods, challenge.Solves); rank++ {
+					m.alert(&AlertData{
+						Event:     EventFirstBlood,
+						Challenge: challenge,
+						Blood:     rank,
+						OurTeam:   ours,
+					})
+				}
+			}
+			continue
+		}
+
+		blooded := make(map[int]bool)
+		if bloods {
+			for rank := previous + 1; rank <= min(m.bloods, len(solves.Data)); rank++ {
+				solve := solves.Data[rank-1]
+				blooded[solve.AccountID] = true
+				m.alert(&AlertData{
+					Event:     EventFirstBlood,
+					Challenge: challenge,
+					Solve:     &solve,
+					Blood:     rank,
+					OurTeam:   solve.AccountID == ourAccount,
+				})
+			}
+		}
+
+		newSolvers := m.newSolvers(challenge.ID, previous, solves.Data)
+		if !m.solveFeed {
+			continue
+		}
+
+		for _, solve := range newSolvers {
+			// Rivals' bloods were already alerted above
+			if solve.AccountID == ourAccount || blooded[solve.AccountID] {
+				continue
+			}
+
+			data := &AlertData{
+				Event:     EventSolve,
+				Challenge: challenge,
+				Solve:     &solve,
+			}
+			for _, team := range scoreboard.Data {
+				if team.AccountID == solve.AccountID {
+					data.Teams = []clients.TeamStanding{team}
+				}
+			}
+			m.alert(data)
+		}
+	}
+}
+
+// newSolvers returns the solves whose accounts weren't known to have solved the challenge
+// and records them. The first time a challenge is seen its earliest previous solves are
+// assumed known, so solves from before serve started aren't alerted.
+func (m *monitor) newSolvers(challengeID, previous int, solves []clients.ChallengeSolve) []clients.ChallengeSolve {
+	if m.state.KnownSolvers == nil {
+		m.state.KnownSolvers = make(map[int][]int)
+	}
+
+	known, ok := m.state.KnownSolvers[challengeID]
+	if !ok {
+		for _, solve := range solves[:min(previous, len(solves))] {
+			known = append(known, solve.AccountID)
+		}
+	}
+
+	var newOnes []clients.ChallengeSolve
+	for _, solve := range solves {
+		if !slices.Contains(known, solve.AccountID) {
+			known = append(known, solve.AccountID)
+			newOnes = append(newOnes, solve)
+		}
+	}
+
+	m.state.KnownSolvers[challengeID] = known
+	return newOnes
+}
+
+// findUserAccountID returns the account ID of the user's scoreboard entry, or 0 if unranked
+func findUserAccountID(scoreboard *clients.ScoreboardResponse, username string) int {
+	if team := findTeam(scoreboard, findUserPosition(scoreboard, username)); team != nil {
+		return team.AccountID
+	}
+	return 0
+}
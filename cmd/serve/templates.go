@@ -0,0 +1,328 @@
+package serve
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// Event kinds emitted by the monitor
+const (
+	EventBypass       = "bypass"
+	EventNewChallenge = "new_challenge"
+	EventAnnouncement = "announcement"
+	EventSolve        = "solve"
+	EventFirstBlood   = "first_blood"
+	EventTeamSolve    = "team_solve"
+	EventValueDrop    = "value_drop"
+)
+
+// AlertTemplate holds the text/template sources used to render one kind of alert.
+// Empty fields fall back to the built-in defaults. Tags and priority come from the
+// event's policy unless their templates are set.
+type AlertTemplate struct {
+	Title    string `toml:"title"`
+	Body     string `toml:"body"`
+	Tags     string `toml:"tags"` // comma separated after rendering
+	Priority string `toml:"priority"`
+	Click    string `toml:"click"`
+}
+
+// AlertData is the data model exposed to alert templates
+type AlertData struct {
+	Event        string                      // event kind, e.g. "bypass"
+	User         string                      // the monitored user or team
+	Site         string                      // CTFd web root derived from the API base
+	Challenge    *clients.Challenge          // challenge the event is about, if any
+	ChallengeURL string                      // link to Challenge on the CTFd challenges page
+	OldPosition  int                         // user's position before the event
+	NewPosition  int                         // user's position after the event
+	Team         *clients.TeamStanding       // user's current scoreboard entry, if ranked
+	Teams        []clients.TeamStanding      // other teams involved, e.g. those that passed the user
+	OldScore     int                         // user's score before the event
+	Score        int                         // user's score after the event
+	Scoreboard   *clients.ScoreboardResponse // scoreboard at the time of the event
+	Notification *clients.Notification       // admin announcement, if any
+	Solve        *clients.ChallengeSolve     // solve of Challenge, nil for bloods when the CTF hides solvers
+	Blood        int                         // 1 for first blood, 2 for second and 3 for third
+	OurTeam      bool                        // whether the user's team got the blood
+	OldValue     int                         // Challenge's value before it dropped
+}
+
+// DefaultTemplates are used for any event or field not overridden in the config
+var DefaultTemplates = map[string]AlertTemplate{
+	EventBypass: {
+		Title: "CTFd Leaderboard Alert",
+		Body:  "🏆 You've been bypassed on the leaderboard! New position: #{{.NewPosition}} (was #{{.OldPosition}})",
+		Click: "{{.Site}}/scoreboard",
+	},
+	EventNewChallenge: {
+		Title: "New CTFd Challenge",
+		Body:  "🎯 New challenge released: {{.Challenge.Name}} ({{.Challenge.Category}}) - {{.Challenge.Value}} points",
+		Click: "{{.ChallengeURL}}",
+	},
+	EventAnnouncement: {
+		Title: "📢 {{.Notification.Title}}",
+		Body:  "{{.Notification.Content}}",
+		Click: "{{.Site}}/notifications",
+	},
+	EventSolve: {
+		Title: "{{.Solve.Name}} solved {{.Challenge.Name}}",
+		Body:  "⚔️ {{.Solve.Name}} solved {{.Challenge.Name}} ({{.Challenge.Category}}) - {{.Challenge.Solves}} solves, now worth {{.Challenge.Value}} points",
+		Click: "{{.ChallengeURL}}",
+	},
+	EventFirstBlood: {
+		Title: "{{.Challenge.Name}}: {{ordinal .Blood}} blood",
+		Body:  "🩸 {{if .OurTeam}}We{{else if .Solve}}{{.Solve.Name}}{{else}}Someone{{end}} got {{ordinal .Blood}} blood on {{.Challenge.Name}} ({{.Challenge.Category}}) - {{.Challenge.Value}} points",
+		Click: "{{.ChallengeURL}}",
+	},
+	EventTeamSolve: {
+		Title: "Solved {{.Challenge.Name}}",
+		Body:  "🎉 {{.User}} solved {{.Challenge.Name}} ({{.Challenge.Category}}) for {{.Challenge.Value}} points! Now #{{.NewPosition}} with {{.Score}} points{{if lt .NewPosition .OldPosition}} (up from #{{.OldPosition}}){{end}}",
+		Click: "{{.ChallengeURL}}",
+	},
+	EventValueDrop: {
+		Title: "{{.Challenge.Name}} dropped to {{.Challenge.Value}} points",
+		Body:  "📉 {{.Challenge.Name}} ({{.Challenge.Category}}) dropped from {{.OldValue}} to {{.Challenge.Value}} points after {{.Challenge.Solves}} solves",
+		Click: "{{.ChallengeURL}}",
+	},
+}
+
+// templateFuncs are available in every alert template
+var templateFuncs = template.FuncMap{
+	// ordinal spells out a blood rank, e.g. 1 becomes "first"
+	"ordinal": func(n int) string {
+		switch n {
+		case 1:
+			return "first"
+		case 2:
+			return "second"
+		case 3:
+			return "third"
+		}
+		return fmt.Sprintf("#%d", n)
+	},
+}
+
+// compiledTemplate is the parsed form of an AlertTemplate
+type compiledTemplate struct {
+	title, body, tags, priority, click *template.Template
+}
+
+// Templates renders AlertData into alerts using per-event templates and policies
+type Templates struct {
+	events   map[string]*compiledTemplate
+	policies map[string]EventPolicy
+}
+
+// ParseTemplates merges the config overrides onto the default templates and policies
+// and parses every template. Unknown event kinds and invalid templates are reported as errors.
+func ParseTemplates(overrides map[string]AlertTemplate, policyOverrides map[string]EventPolicy) (*Templates, error) {
+	for event := range overrides {
+		if _, ok := DefaultTemplates[event]; !ok {
+			return nil, fmt.Errorf("unknown alert template %q", event)
+		}
+	}
+
+	policies, err := mergePolicies(policyOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{
+		events:   make(map[string]*compiledTemplate),
+		policies: policies,
+	}
+	for event, def := range DefaultTemplates {
+		merged := def
+		if override, ok := overrides[event]; ok {
+			if override.Title != "" {
+				merged.Title = override.Title
+			}
+			if override.Body != "" {
+				merged.Body = override.Body
+			}
+			if override.Tags != "" {
+				merged.Tags = override.Tags
+			}
+			if override.Priority != "" {
+				merged.Priority = override.Priority
+			}
+			if override.Click != "" {
+				merged.Click = override.Click
+			}
+		}
+
+		compiled := &compiledTemplate{}
+		for _, field := range []struct {
+			name   string
+			source string
+			target **template.Template
+		}{
+			{"title", merged.Title, &compiled.title},
+			{"body", merged.Body, &compiled.body},
+			{"tags", merged.Tags, &compiled.tags},
+			{"priority", merged.Priority, &compiled.priority},
+			{"click", merged.Click, &compiled.click},
+		} {
+			parsed, err := template.New(event + "." + field.name).Funcs(templateFuncs).Parse(field.source)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s template: %v", event, err)
+			}
+			*field.target = parsed
+		}
+
+		t.events[event] = compiled
+	}
+
+	return t, nil
+}
+
+// execute renders a single template field to a trimmed string
+func execute(tmpl *template.Template, data *AlertData) (string, error) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// Render builds an alert for the event described by data
+func (t *Templates) Render(data *AlertData) (*clients.Alert, error) {
+	compiled, ok := t.events[data.Event]
+	if !ok {
+		return nil, fmt.Errorf("no template for event %q", data.Event)
+	}
+
+	policy := t.policies[data.Event]
+	alert := &clients.Alert{
+		Event:            data.Event,
+		Priority:         policy.Priority,
+		Tags:             slices.Clone(policy.Tags),
+		Icon:             policy.Icon,
+		Challenge:        data.Challenge,
+		Position:         data.NewPosition,
+		PreviousPosition: data.OldPosition,
+		// Announcements are written in markdown on CTFd
+		Markdown: data.Event == EventAnnouncement,
+	}
+
+	var err error
+	if alert.Title, err = execute(compiled.title, data); err != nil {
+		return nil, fmt.Errorf("error rendering %s title: %v", data.Event, err)
+	}
+	if alert.Body, err = execute(compiled.body, data); err != nil {
+		return nil, fmt.Errorf("error rendering %s body: %v", data.Event, err)
+	}
+	if alert.Click, err = execute(compiled.click, data); err != nil {
+		return nil, fmt.Errorf("error rendering %s click: %v", data.Event, err)
+	}
+
+	tags, err := execute(compiled.tags, data)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering %s tags: %v", data.Event, err)
+	}
+	if tags != "" {
+		alert.Tags = nil
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				alert.Tags = append(alert.Tags, tag)
+			}
+		}
+	}
+
+	priority, err := execute(compiled.priority, data)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering %s priority: %v", data.Event, err)
+	}
+	if priority != "" {
+		if alert.Priority, err = strconv.Atoi(priority); err != nil {
+			return nil, fmt.Errorf("%s priority %q is not a number", data.Event, priority)
+		}
+	}
+
+	return alert, nil
+}
+
+// SampleData returns example data for every event kind, used to test notifiers
+func SampleData(user, site string) []*AlertData {
+	challenge := &clients.Challenge{
+		ID:       1,
+		Name:     "Sample Challenge",
+		Category: "web",
+		Value:    500,
+		Solves:   3,
+	}
+	team := &clients.TeamStanding{Position: 5, Name: user, Score: 1200}
+	rival := clients.TeamStanding{Position: 4, Name: "Sample Rival", Score: 1300}
+
+	samples := []*AlertData{
+		{
+			Event:       EventBypass,
+			OldPosition: 4,
+			NewPosition: 5,
+			Team:        team,
+			Teams:       []clients.TeamStanding{rival},
+			OldScore:    1200,
+			Score:       1200,
+			Scoreboard: &clients.ScoreboardResponse{
+				Success: true,
+				Data:    []clients.TeamStanding{rival, *team},
+			},
+		},
+		{
+			Event:     EventNewChallenge,
+			Challenge: challenge,
+		},
+		{
+			Event: EventAnnouncement,
+			Notification: &clients.Notification{
+				ID:      1,
+				Title:   "Sample Announcement",
+				Content: "The flag format for **Sample Challenge** is `flag{...}`",
+			},
+		},
+		{
+			Event:     EventSolve,
+			Challenge: challenge,
+			Solve:     &clients.ChallengeSolve{AccountID: 2, Name: rival.Name},
+			Teams:     []clients.TeamStanding{rival},
+		},
+		{
+			Event:     EventFirstBlood,
+			Challenge: challenge,
+			Solve:     &clients.ChallengeSolve{AccountID: 1, Name: user},
+			Blood:     1,
+			OurTeam:   true,
+		},
+		{
+			Event:       EventTeamSolve,
+			Challenge:   challenge,
+			OldPosition: 5,
+			NewPosition: 4,
+			Team:        &clients.TeamStanding{Position: 4, Name: user, Score: 1700},
+			OldScore:    1200,
+			Score:       1700,
+		},
+		{
+			Event:     EventValueDrop,
+			Challenge: challenge,
+			OldValue:  650,
+		},
+	}
+
+	for _, data := range samples {
+		data.User = user
+		data.Site = site
+		if data.Challenge != nil {
+			data.ChallengeURL = challengeURL(site, *data.Challenge)
+		}
+	}
+
+	return samples
+}
@@ -0,0 +1,129 @@
+package serve
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// valueHistoryLimit bounds how many value changes are remembered per challenge
+const valueHistoryLimit = 20
+
+// ValueAlertConfig configures alerts for dynamic challenges losing value as they are solved
+type ValueAlertConfig struct {
+	// Alert when an unsolved challenge drops below this many points
+	Below int `toml:"below"`
+	// Alert when an unsolved challenge loses this percentage of its value since the last alert
+	Percent float64 `toml:"percent"`
+}
+
+// ValueAlerts detects unsolved challenges whose value dropped enough to alert on
+type ValueAlerts struct {
+	below   int
+	percent float64
+}
+
+// ValuePoint is a challenge's value at a point in time
+type ValuePoint struct {
+	Time  time.Time `json:"time"`
+	Value int       `json:"value"`
+}
+
+// ParseValueAlerts validates the config. Returns nil if value alerts are not configured.
+func ParseValueAlerts(cfg ValueAlertConfig) (*ValueAlerts, error) {
+	if cfg.Below == 0 && cfg.Percent == 0 {
+		return nil, nil
+	}
+
+	if cfg.Below < 0 {
+		return nil, fmt.Errorf("value_alerts below cannot be negative")
+	}
+
+	if cfg.Percent < 0 || cfg.Percent >= 100 {
+		return nil, fmt.Errorf("value_alerts percent must be between 0 and 100")
+	}
+
+	return &ValueAlerts{below: cfg.Below, percent: cfg.Percent}, nil
+}
+
+// Check compares the challenge values with the last poll and returns an event for every
+// unsolved challenge that dropped below the threshold or lost the configured percentage.
+// Percentages are measured from the value at the last alert, or when the challenge was first seen.
+func (v *ValueAlerts) Check(state *MonitorState, oldChallenges, newChallenges *clients.ChallengeListResponse) []*AlertData {
+	if state.ValueBaselines == nil {
+		state.ValueBaselines = make(map[int]int)
+	}
+
+	previousValues := make(map[int]int)
+	for _, challenge := range oldChallenges.Data {
+		previousValues[challenge.ID] = challenge.Value
+	}
+
+	var events []*AlertData
+	for i := range newChallenges.Data {
+		challenge := &newChallenges.Data[i]
+		baseline, ok := state.ValueBaselines[challenge.ID]
+		if !ok {
+			state.ValueBaselines[challenge.ID] = challenge.Value
+			continue
+		}
+
+		previous, ok := previousValues[challenge.ID]
+		if v == nil || !ok || challenge.SolvedByMe || challenge.Value >= previous {
+			continue
+		}
+
+		var oldValue int
+		switch {
+		case v.below > 0 && previous >= v.below && challenge.Value < v.below:
+			oldValue = previous
+		case v.percent > 0 && baseline > 0 && float64(baseline-challenge.Value)/float64(baseline)*100 >= v.percent:
+			oldValue = baseline
+		default:
+			continue
+		}
+
+		state.ValueBaselines[challenge.ID] = challenge.Value
+		events = append(events, &AlertData{
+			Event:     EventValueDrop,
+			Challenge: challenge,
+			OldValue:  oldValue,
+		})
+	}
+
+	return events
+}
+
+// recordValues adds every challenge whose value changed to its value history.
+// The first value seen is kept separately since the history only holds recent changes.
+func (s *MonitorState) recordValues(challenges *clients.ChallengeListResponse, now time.Time) {
+	if s.ValueHistory == nil {
+		s.ValueHistory = make(map[int][]ValuePoint)
+	}
+	if s.FirstValues == nil {
+		s.FirstValues = make(map[int]int)
+	}
+
+	for _, challenge := range challenges.Data {
+		if _, ok := s.FirstValues[challenge.ID]; !ok {
+			// Caches from before first values were kept still have the oldest change recorded
+			if history := s.ValueHistory[challenge.ID]; len(history) > 0 {
+				s.FirstValues[challenge.ID] = history[0].Value
+			} else {
+				s.FirstValues[challenge.ID] = challenge.Value
+			}
+		}
+
+		history := s.ValueHistory[challenge.ID]
+		if len(history) > 0 && history[len(history)-1].Value == challenge.Value {
+			continue
+		}
+
+		history = append(history, ValuePoint{Time: now, Value: challenge.Value})
+		if len(history) > valueHistoryLimit {
+			history = history[len(history)-valueHistoryLimit:]
+		}
+		s.ValueHistory[challenge.ID] = history
+	}
+}
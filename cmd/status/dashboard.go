@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss/table"
 	"github.com/spf13/cobra"
 	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+	"github.com/taciturnaxolotl/ctfd-alerts/cmd/serve"
 )
 
 var (
@@ -49,6 +50,19 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// valueTrend describes how a challenge's value changed since serve first saw it
+func valueTrend(first, value int) string {
+	if first == value || first == 0 {
+		return ""
+	}
+
+	change := float64(value-first) / float64(first) * 100
+	if value < first {
+		return fmt.Sprintf("↓%d (%.0f%%)", first-value, change)
+	}
+	return fmt.Sprintf("↑%d (+%.0f%%)", value-first, change)
+}
+
 func createTable(headers []string, rows [][]string) string {
 
 	t := table.New().
@@ -70,6 +84,24 @@ func createTable(headers []string, rows [][]string) string {
 	return t.Render()
 }
 
+// Title renders a section title in the dashboard style
+func Title(text string) string {
+	return titleStyle.Render(text)
+}
+
+// Table renders rows in the dashboard table style
+func Table(headers []string, rows [][]string) string {
+	return createTable(headers, rows)
+}
+
+// Mark renders a green check or a red cross
+func Mark(ok bool) string {
+	if ok {
+		return solvedStyle.String()
+	}
+	return unsolvedStyle.String()
+}
+
 func runDashboard(cmd *cobra.Command, args []string) {
 	// Get CTFd client from root command context
 	ctfdClient := cmd.Context().Value("ctfd_client").(CTFdClient)
@@ -103,8 +135,14 @@ func runDashboard(cmd *cobra.Command, args []string) {
 		log.Fatalf("Error fetching challenges: %v", err)
 	}
 
+	// First values recorded by serve, if it has run here
+	var firstValues map[int]int
+	if state, err := serve.LoadState(); err == nil {
+		firstValues = state.FirstValues
+	}
+
 	// Prepare challenge data
-	challengeHeaders := []string{"ID", "Name", "Category", "Value", "Solves", "Solved"}
+	challengeHeaders := []string{"ID", "Name", "Category", "Value", "Trend", "Solves", "Solved"}
 	challengeRows := make([][]string, len(challenges.Data))
 
 	for i, challenge := range challenges.Data {
@@ -117,6 +155,7 @@ func runDashboard(cmd *cobra.Command, args []string) {
 			truncateString(challenge.Name, 24),
 			truncateString(challenge.Category, 14),
 			fmt.Sprintf("%d", challenge.Value),
+			valueTrend(firstValues[challenge.ID], challenge.Value),
 			fmt.Sprintf("%d", challenge.Solves),
 			solvedStatus,
 		}
@@ -136,6 +175,25 @@ func runDashboard(cmd *cobra.Command, args []string) {
 	dashboard.WriteString("\n")
 	dashboard.WriteString(createTable(challengeHeaders, challengeRows))
 
+	// Pending alerts section, only shown when serve has undelivered alerts
+	if outbox := serve.LoadOutbox(); outbox.Pending() > 0 {
+		dashboard.WriteString("\n\n")
+		dashboard.WriteString(titleStyle.Render(fmt.Sprintf("Pending Alerts [%d]", outbox.Pending())))
+		dashboard.WriteString("\n")
+
+		outboxRows := make([][]string, len(outbox.Entries))
+		for i, entry := range outbox.Entries {
+			outboxRows[i] = []string{
+				truncateString(entry.Alert.Title, 24),
+				entry.Notifier,
+				fmt.Sprintf("%d", entry.Attempts),
+				entry.NextAttempt.Format("15:04:05"),
+				truncateString(entry.LastError, 39),
+			}
+		}
+		dashboard.WriteString(createTable([]string{"Alert", "Notifier", "Attempts", "Next Try", "Last Error"}, outboxRows))
+	}
+
 	// Render the final output
 	fmt.Print("\n")
 	fmt.Print(dashboard.String())
@@ -7,25 +7,112 @@ import (
 	"strings"
 
 	"github.com/pelletier/go-toml/v2"
+	"github.com/taciturnaxolotl/ctfd-alerts/cmd/serve"
 )
 
 type CTFdConfig struct {
 	ApiBase string `toml:"api_base"`
 	ApiKey  string `toml:"api_key"`
+	// Alert when other teams solve challenges
+	SolveFeed bool `toml:"solve_feed"`
+	// How many bloods to alert on per challenge: 1 for first blood only, up to 3
+	Bloods int `toml:"bloods"`
 }
 
 type NtfyConfig struct {
 	ApiBase     string `toml:"api_base"`
 	AccessToken string `toml:"acess_token"`
 	Topic       string `toml:"topic"`
+	// Topic that serve listens on for chat-ops commands, disabled when empty
+	ControlTopic string `toml:"control_topic"`
+	// Attach a PNG of the scoreboard around the user to bypass alerts
+	AttachScoreboard bool `toml:"attach_scoreboard"`
+}
+
+type DiscordConfig struct {
+	WebhookURL string `toml:"webhook_url"`
+	Username   string `toml:"username"`
+}
+
+type SlackConfig struct {
+	WebhookURL string `toml:"webhook_url"`
+}
+
+type MatrixConfig struct {
+	Homeserver  string `toml:"homeserver"`
+	AccessToken string `toml:"access_token"`
+	RoomID      string `toml:"room_id"`
+}
+
+type TelegramConfig struct {
+	ApiBase        string `toml:"api_base"`
+	BotToken       string `toml:"bot_token"`
+	ChatID         string `toml:"chat_id"`
+	DisablePreview bool   `toml:"disable_preview"`
+}
+
+type EmailConfig struct {
+	Host     string   `toml:"host"`
+	Port     int      `toml:"port"`
+	Username string   `toml:"username"`
+	Password string   `toml:"password"`
+	From     string   `toml:"from"`
+	To       []string `toml:"to"`
+	TLS      string   `toml:"tls"`
+	// Skips certificate verification, only for relays with self-signed certificates
+	InsecureSkipVerify bool `toml:"insecure_skip_verify"`
+	ImmediatePriority  int  `toml:"immediate_priority"`
+	DigestInterval     int  `toml:"digest_interval"`
+}
+
+type WebhookConfig struct {
+	URL      string            `toml:"url"`
+	Method   string            `toml:"method"`
+	Template string            `toml:"template"`
+	Headers  map[string]string `toml:"headers"`
+	Secret   string            `toml:"secret"`
+}
+
+type GotifyConfig struct {
+	ApiBase  string `toml:"api_base"`
+	AppToken string `toml:"app_token"`
+}
+
+type PushoverConfig struct {
+	ApiBase  string `toml:"api_base"`
+	AppToken string `toml:"app_token"`
+	UserKey  string `toml:"user_key"`
+}
+
+type DesktopConfig struct {
+	AppName string `toml:"app_name"`
+	Icon    string `toml:"icon"`
+	Timeout int32  `toml:"timeout"`
 }
 
 type Config struct {
-	Debug           bool       `toml:"debug"`
-	User            string     `toml:"user"`
-	CTFdConfig      CTFdConfig `toml:"ctfd"`
-	NtfyConfig      NtfyConfig `toml:"ntfy"`
-	MonitorInterval int        `toml:"interval"`
+	Debug           bool                           `toml:"debug"`
+	User            string                         `toml:"user"`
+	CTFdConfig      CTFdConfig                     `toml:"ctfd"`
+	NtfyConfig      NtfyConfig                     `toml:"ntfy"`
+	DiscordConfig   DiscordConfig                  `toml:"discord"`
+	SlackConfig     SlackConfig                    `toml:"slack"`
+	MatrixConfig    MatrixConfig                   `toml:"matrix"`
+	TelegramConfig  TelegramConfig                 `toml:"telegram"`
+	EmailConfig     EmailConfig                    `toml:"email"`
+	WebhookConfig   WebhookConfig                  `toml:"webhook"`
+	GotifyConfig    GotifyConfig                   `toml:"gotify"`
+	PushoverConfig  PushoverConfig                 `toml:"pushover"`
+	DesktopConfig   DesktopConfig                  `toml:"desktop"`
+	MonitorInterval int                            `toml:"interval"`
+	Notifiers       []string                       `toml:"notifiers"`
+	Destinations    []string                       `toml:"destinations"`
+	Templates       map[string]serve.AlertTemplate `toml:"templates"`
+	Cooldowns       map[string]string              `toml:"cooldowns"`
+	QuietHours      serve.QuietHoursConfig         `toml:"quiet_hours"`
+	Routes          []serve.Route                  `toml:"routes"`
+	Policies        map[string]serve.EventPolicy   `toml:"policy"`
+	ValueAlerts     serve.ValueAlertConfig         `toml:"value_alerts"`
 }
 
 var config *Config
@@ -54,18 +141,155 @@ func loadConfig(path string) (*Config, error) {
 		return nil, errors.New("ctfd api_key must be in the format ctfd_<64 hex characters> not " + cfg.CTFdConfig.ApiKey)
 	}
 
-	if cfg.NtfyConfig.ApiBase == "" {
-		return nil, errors.New("ntfy api_base URL cannot be empty")
+	if cfg.NtfyConfig.ControlTopic != "" && cfg.NtfyConfig.ApiBase == "" {
+		return nil, errors.New("ntfy api_base URL cannot be empty when control_topic is set")
+	}
+
+	// serve would read its own alerts back as commands
+	if cfg.NtfyConfig.ControlTopic != "" && cfg.NtfyConfig.ControlTopic == cfg.NtfyConfig.Topic {
+		return nil, errors.New("ntfy control_topic must be different from topic")
 	}
 
-	if cfg.NtfyConfig.Topic == "" {
-		return nil, errors.New("ntfy topic cannot be empty")
+	for i, route := range cfg.Routes {
+		if cfg.NtfyConfig.ControlTopic != "" && route.Topic == cfg.NtfyConfig.ControlTopic {
+			return nil, fmt.Errorf("route %d: topic must be different from ntfy control_topic", i+1)
+		}
+	}
+
+	if len(cfg.Notifiers) == 0 && len(cfg.Destinations) == 0 {
+		cfg.Notifiers = []string{"ntfy"}
+	}
+
+	for _, name := range cfg.Notifiers {
+		switch name {
+		case "ntfy":
+			if cfg.NtfyConfig.ApiBase == "" {
+				return nil, errors.New("ntfy api_base URL cannot be empty")
+			}
+
+			if cfg.NtfyConfig.Topic == "" {
+				return nil, errors.New("ntfy topic cannot be empty")
+			}
+		case "discord":
+			if cfg.DiscordConfig.WebhookURL == "" {
+				return nil, errors.New("discord webhook_url cannot be empty")
+			}
+		case "slack":
+			if cfg.SlackConfig.WebhookURL == "" {
+				return nil, errors.New("slack webhook_url cannot be empty")
+			}
+		case "matrix":
+			if cfg.MatrixConfig.Homeserver == "" {
+				return nil, errors.New("matrix homeserver URL cannot be empty")
+			}
+
+			if cfg.MatrixConfig.AccessToken == "" {
+				return nil, errors.New("matrix access_token cannot be empty")
+			}
+
+			if cfg.MatrixConfig.RoomID == "" {
+				return nil, errors.New("matrix room_id cannot be empty")
+			}
+		case "telegram":
+			if cfg.TelegramConfig.BotToken == "" {
+				return nil, errors.New("telegram bot_token cannot be empty")
+			}
+
+			if cfg.TelegramConfig.ChatID == "" {
+				return nil, errors.New("telegram chat_id cannot be empty")
+			}
+		case "email":
+			if cfg.EmailConfig.Host == "" {
+				return nil, errors.New("email host cannot be empty")
+			}
+
+			if cfg.EmailConfig.From == "" {
+				return nil, errors.New("email from address cannot be empty")
+			}
+
+			if len(cfg.EmailConfig.To) == 0 {
+				return nil, errors.New("email needs at least one to address")
+			}
+
+			switch cfg.EmailConfig.TLS {
+			case "":
+				cfg.EmailConfig.TLS = "starttls"
+			case "starttls", "tls", "none":
+			default:
+				return nil, fmt.Errorf("email tls must be one of starttls, tls or none not %q", cfg.EmailConfig.TLS)
+			}
+
+			if cfg.EmailConfig.Port == 0 {
+				cfg.EmailConfig.Port = 587
+				if cfg.EmailConfig.TLS == "tls" {
+					cfg.EmailConfig.Port = 465
+				}
+			}
+
+			if cfg.EmailConfig.ImmediatePriority == 0 {
+				cfg.EmailConfig.ImmediatePriority = 4
+			}
+
+			if cfg.EmailConfig.DigestInterval == 0 {
+				cfg.EmailConfig.DigestInterval = 60
+			}
+		case "webhook":
+			if cfg.WebhookConfig.URL == "" {
+				return nil, errors.New("webhook url cannot be empty")
+			}
+		case "gotify":
+			if cfg.GotifyConfig.ApiBase == "" {
+				return nil, errors.New("gotify api_base URL cannot be empty")
+			}
+
+			if cfg.GotifyConfig.AppToken == "" {
+				return nil, errors.New("gotify app_token cannot be empty")
+			}
+		case "pushover":
+			if cfg.PushoverConfig.AppToken == "" {
+				return nil, errors.New("pushover app_token cannot be empty")
+			}
+
+			if cfg.PushoverConfig.UserKey == "" {
+				return nil, errors.New("pushover user_key cannot be empty")
+			}
+		case "desktop":
+			if cfg.DesktopConfig.Timeout == 0 {
+				cfg.DesktopConfig.Timeout = -1
+			}
+		default:
+			return nil, fmt.Errorf("unknown notifier %q", name)
+		}
 	}
 
 	if cfg.User == "" {
 		return nil, errors.New("user cannot be empty")
 	}
 
+	if cfg.CTFdConfig.Bloods == 0 {
+		cfg.CTFdConfig.Bloods = 1
+	}
+
+	if cfg.CTFdConfig.Bloods < 1 || cfg.CTFdConfig.Bloods > 3 {
+		return nil, errors.New("ctfd bloods must be between 1 and 3")
+	}
+
+	if _, err := serve.ParseTemplates(cfg.Templates, cfg.Policies); err != nil {
+		return nil, err
+	}
+
+	if _, err := serve.ParseCooldowns(cfg.Cooldowns); err != nil {
+		return nil, err
+	}
+
+	if _, err := serve.ParseQuietHours(cfg.QuietHours); err != nil {
+		return nil, err
+	}
+
+	if _, err := serve.ParseValueAlerts(cfg.ValueAlerts); err != nil {
+		return nil, err
+	}
+
 	if cfg.MonitorInterval == 0 {
 		cfg.MonitorInterval = 300
 		fmt.Println("you haven't set a monitor interval; setting to 300")
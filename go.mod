@@ -5,8 +5,10 @@ go 1.24.3
 require (
 	github.com/charmbracelet/fang v0.2.0
 	github.com/charmbracelet/lipgloss v1.1.0
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/pelletier/go-toml/v2 v2.2.4
 	github.com/spf13/cobra v1.9.1
+	golang.org/x/image v0.28.0
 )
 
 require (
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
 	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+	"github.com/taciturnaxolotl/ctfd-alerts/cmd/notify"
 	"github.com/taciturnaxolotl/ctfd-alerts/cmd/serve"
 	"github.com/taciturnaxolotl/ctfd-alerts/cmd/status"
 )
@@ -43,6 +44,13 @@ you or a new challenge is announced. You can also use the fancy status command :
 		ctfdClient := clients.NewCTFdClient(config.CTFdConfig.ApiBase, config.CTFdConfig.ApiKey)
 		ctx := context.WithValue(cmd.Context(), "ctfd_client", ctfdClient)
 		ctx = context.WithValue(ctx, "config", config)
+
+		// Create every enabled notifier and add them to context
+		notifiers, err := buildNotifiers(config)
+		if err != nil {
+			log.Fatalf("Error creating notifiers: %v", err)
+		}
+		ctx = context.WithValue(ctx, "notifiers", notifiers)
 		cmd.SetContext(ctx)
 	},
 }
@@ -54,6 +62,7 @@ func init() {
 	// Add commands
 	cmd.AddCommand(status.StatusCmd)
 	cmd.AddCommand(serve.ServeCmd)
+	cmd.AddCommand(notify.NotifyCmd)
 }
 
 func main() {
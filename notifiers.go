@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taciturnaxolotl/ctfd-alerts/clients"
+)
+
+// buildNotifiers creates a notifier for every backend listed in the config
+// and for every destination URL
+func buildNotifiers(cfg *Config) ([]clients.Notifier, error) {
+	notifiers := make([]clients.Notifier, 0, len(cfg.Notifiers)+len(cfg.Destinations))
+	for _, name := range cfg.Notifiers {
+		switch name {
+		case "ntfy":
+			notifiers = append(notifiers, clients.NewNtfyClient(cfg.NtfyConfig.Topic, cfg.NtfyConfig.ApiBase, cfg.NtfyConfig.AccessToken))
+		case "discord":
+			notifiers = append(notifiers, clients.NewDiscordClient(cfg.DiscordConfig.WebhookURL, cfg.DiscordConfig.Username))
+		case "slack":
+			notifiers = append(notifiers, clients.NewSlackClient(cfg.SlackConfig.WebhookURL))
+		case "matrix":
+			notifiers = append(notifiers, clients.NewMatrixClient(cfg.MatrixConfig.Homeserver, cfg.MatrixConfig.AccessToken, cfg.MatrixConfig.RoomID))
+		case "telegram":
+			notifiers = append(notifiers, clients.NewTelegramClient(cfg.TelegramConfig.ApiBase, cfg.TelegramConfig.BotToken, cfg.TelegramConfig.ChatID, cfg.TelegramConfig.DisablePreview))
+		case "email":
+			email := cfg.EmailConfig
+			notifiers = append(notifiers, clients.NewEmailClient(email.Host, email.Port, email.Username, email.Password, email.From, email.To,
+				email.TLS, email.InsecureSkipVerify, email.ImmediatePriority, time.Duration(email.DigestInterval)*time.Minute))
+		case "webhook":
+			webhook := cfg.WebhookConfig
+			client, err := clients.NewWebhookClient(webhook.URL, webhook.Method, webhook.Template, webhook.Headers, webhook.Secret)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, client)
+		case "gotify":
+			notifiers = append(notifiers, clients.NewGotifyClient(cfg.GotifyConfig.ApiBase, cfg.GotifyConfig.AppToken))
+		case "pushover":
+			notifiers = append(notifiers, clients.NewPushoverClient(cfg.PushoverConfig.ApiBase, cfg.PushoverConfig.AppToken, cfg.PushoverConfig.UserKey))
+		case "desktop":
+			notifiers = append(notifiers, clients.NewDesktopClient(cfg.DesktopConfig.AppName, cfg.DesktopConfig.Icon, cfg.DesktopConfig.Timeout))
+		}
+	}
+
+	for _, destination := range cfg.Destinations {
+		notifier, err := clients.ParseNotifierURL(destination)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+
+	// Names identify notifiers in logs, so they have to be unique
+	names := make(map[string]bool)
+	for _, notifier := range notifiers {
+		if names[notifier.Name()] {
+			return nil, fmt.Errorf("duplicate notifier %q; name destinations with a #fragment", notifier.Name())
+		}
+		names[notifier.Name()] = true
+	}
+
+	return notifiers, nil
+}